@@ -0,0 +1,277 @@
+package htmlsimple
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// A11yIssue is an accessibility problem found by CheckAccessibility.
+type A11yIssue struct {
+	// Path is the location of the offending element, see Element.Path.
+	Path string
+	// Rule names the check that failed, such as "img-alt" or
+	// "heading-order".
+	Rule   string
+	Reason string
+}
+
+func (i A11yIssue) Error() string {
+	return fmt.Sprintf("%s: %s (%s)", i.Path, i.Reason, i.Rule)
+}
+
+// CheckAccessibility walks the tree below root and reports common,
+// WCAG-oriented accessibility problems in document order:
+//
+//   - img-alt: <img>, <area> and <input type="image"> without alt
+//   - control-label: form controls without a label or accessible name
+//   - button-name: buttons without text or accessible name
+//   - duplicate-id: an id used more than once
+//   - aria-role: a role that is not a WAI-ARIA role
+//   - aria-attribute: an unknown aria-* attribute, an invalid value or a
+//     reference to a missing id
+//   - aria-allowed: an aria-* attribute the element's role does not support
+//   - heading-order: a heading more than one level below the previous one
+//   - html-lang: <html> without lang
+//
+// The checks only see the tree, not the CSS, so they catch what is
+// certainly wrong rather than prove a page accessible. In a unit test:
+//
+//	if issues := htmlsimple.CheckAccessibility(g.Root); len(issues) > 0 {
+//		t.Error(issues)
+//	}
+func CheckAccessibility(root *Element) []A11yIssue {
+	c := &a11yChecker{
+		ids:      make(map[string]int),
+		labelFor: make(map[string]bool),
+	}
+	c.collect(root)
+	c.check(root, false)
+	return c.issues
+}
+
+type a11yChecker struct {
+	issues []A11yIssue
+	// ids counts the elements carrying each id.
+	ids map[string]int
+	// labelFor holds the ids referenced by <label for>.
+	labelFor map[string]bool
+	// reported holds the duplicate ids already reported.
+	reported map[string]bool
+	// heading is the level of the previous heading, 0 before the first.
+	heading int
+}
+
+func (c *a11yChecker) report(e *Element, rule, format string, args ...any) {
+	c.issues = append(c.issues, A11yIssue{
+		Path:   e.Path(),
+		Rule:   rule,
+		Reason: fmt.Sprintf(format, args...),
+	})
+}
+
+// collect records the ids and label targets of the tree, so references can
+// be checked regardless of their order.
+func (c *a11yChecker) collect(e *Element) {
+	if id, ok := attrValue(e, "id"); ok && id != "" {
+		c.ids[id]++
+	}
+	if e.Tag.name() == "label" {
+		if target, ok := attrValue(e, "for"); ok {
+			c.labelFor[target] = true
+		}
+	}
+	for _, child := range e.Children {
+		if el, ok := child.(*Element); ok {
+			c.collect(el)
+		}
+	}
+}
+
+func (c *a11yChecker) check(e *Element, inLabel bool) {
+	name := e.Tag.name()
+	if _, ok := e.Tag.(NormalTag); ok || e.isVoid() {
+		c.checkHTML(e, name, inLabel)
+	}
+	c.checkID(e)
+	c.checkAria(e)
+
+	inLabel = inLabel || name == "label"
+	for _, child := range e.Children {
+		if el, ok := child.(*Element); ok {
+			c.check(el, inLabel)
+		}
+	}
+}
+
+// checkHTML runs the checks that apply to HTML elements.
+func (c *a11yChecker) checkHTML(e *Element, name string, inLabel bool) {
+	inputType, _ := attrValue(e, "type")
+	inputType = strings.ToLower(inputType)
+
+	switch name {
+	case "html":
+		if lang, _ := attrValue(e, "lang"); strings.TrimSpace(lang) == "" {
+			c.report(e, "html-lang", "<html> has no lang attribute")
+		}
+	case "img", "area":
+		if _, ok := attrValue(e, "alt"); !ok && !hasAccessibleName(e) {
+			c.report(e, "img-alt", "<%s> has no alt text; use alt=\"\" for decorative images", name)
+		}
+	case "button":
+		if !hasText(e) && !hasAccessibleName(e) {
+			c.report(e, "button-name", "<button> has no text or accessible name")
+		}
+	case "select", "textarea":
+		c.checkLabel(e, name, inLabel)
+	case "input":
+		switch inputType {
+		case "hidden", "submit", "reset":
+		case "image":
+			if alt, _ := attrValue(e, "alt"); alt == "" && !hasAccessibleName(e) {
+				c.report(e, "img-alt", "<input type=\"image\"> has no alt text")
+			}
+		case "button":
+			if value, _ := attrValue(e, "value"); strings.TrimSpace(value) == "" && !hasAccessibleName(e) {
+				c.report(e, "button-name", "<input type=\"button\"> has no value or accessible name")
+			}
+		default:
+			c.checkLabel(e, name, inLabel)
+		}
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		level := int(name[1] - '0')
+		if c.heading > 0 && level > c.heading+1 {
+			c.report(e, "heading-order", "<%s> follows <h%d>, skipping a level", name, c.heading)
+		}
+		c.heading = level
+	}
+}
+
+// checkLabel reports a form control that has no label.
+func (c *a11yChecker) checkLabel(e *Element, name string, inLabel bool) {
+	if inLabel || hasAccessibleName(e) {
+		return
+	}
+	if id, ok := attrValue(e, "id"); ok && c.labelFor[id] {
+		return
+	}
+	c.report(e, "control-label", "<%s> has no label", name)
+}
+
+func (c *a11yChecker) checkID(e *Element) {
+	id, ok := attrValue(e, "id")
+	if !ok || id == "" || c.ids[id] < 2 || c.reported[id] {
+		return
+	}
+	if c.reported == nil {
+		c.reported = make(map[string]bool)
+	}
+	c.reported[id] = true
+	c.report(e, "duplicate-id", "id %q is used by %d elements", id, c.ids[id])
+}
+
+func (c *a11yChecker) checkAria(e *Element) {
+	// The first known role of a role list is the one that applies.
+	var role Role
+	if value, ok := attrValue(e, "role"); ok {
+		for _, r := range strings.Fields(value) {
+			if !ariaRoles[Role(r)] {
+				c.report(e, "aria-role", "role %q is not a WAI-ARIA role", r)
+			} else if role == "" {
+				role = Role(r)
+			}
+		}
+	}
+	if _, ok := e.Tag.(NormalTag); role == "" && (ok || e.isVoid()) {
+		role = implicitRole(e)
+	}
+
+	var keys []string
+	for key := range e.Attributes {
+		if strings.HasPrefix(key, "aria-") {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		attr, ok := ariaAttributes[key]
+		if !ok {
+			c.report(e, "aria-attribute", "%s is not a WAI-ARIA attribute", key)
+			continue
+		}
+		if role != "" && attr.roles != nil && !slices.Contains(attr.roles, role) {
+			c.report(e, "aria-allowed", "%s is not supported by role %s", key, role)
+		}
+		value, _ := attrValue(e, key)
+		if reason := c.checkAriaValue(attr, value); reason != "" {
+			c.report(e, "aria-attribute", "%s %s", key, reason)
+		}
+	}
+}
+
+// checkAriaValue returns why value is not valid for attr, or "".
+func (c *a11yChecker) checkAriaValue(attr ariaAttribute, value string) string {
+	switch {
+	case attr.idrefs:
+		for _, id := range strings.Fields(value) {
+			if c.ids[id] == 0 {
+				return fmt.Sprintf("references missing id %q", id)
+			}
+		}
+	case attr.integer:
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Sprintf("must be an integer, not %q", value)
+		}
+	case attr.number:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Sprintf("must be a number, not %q", value)
+		}
+	case attr.tokens != nil:
+		values := []string{value}
+		if attr.list {
+			values = strings.Fields(value)
+		}
+		for _, v := range values {
+			if !slices.Contains(attr.tokens, v) {
+				return fmt.Sprintf("must be one of %s, not %q", strings.Join(attr.tokens, ", "), v)
+			}
+		}
+	}
+	return ""
+}
+
+// attrValue returns the value of the attribute key of e as it is rendered.
+func attrValue(e *Element, key string) (string, bool) {
+	values, ok := e.Attributes[key]
+	return strings.Join(values, " "), ok
+}
+
+// hasAccessibleName reports whether e is named by ARIA or a title.
+func hasAccessibleName(e *Element) bool {
+	for _, key := range []string{"aria-label", "aria-labelledby", "title"} {
+		if value, _ := attrValue(e, key); strings.TrimSpace(value) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// hasText reports whether e or a descendant has text, counting the alt
+// text of images and the names of labelled descendants.
+func hasText(e *Element) bool {
+	if strings.TrimSpace(e.Content) != "" || hasAccessibleName(e) {
+		return true
+	}
+	if e.Tag.name() == "img" {
+		if alt, _ := attrValue(e, "alt"); strings.TrimSpace(alt) != "" {
+			return true
+		}
+	}
+	for _, child := range e.Children {
+		if el, ok := child.(*Element); ok && hasText(el) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package htmlsimple
+
+import (
+	"slices"
+	"testing"
+)
+
+// issueRules returns "rule@path" for every issue.
+func issueRules(issues []A11yIssue) []string {
+	var rules []string
+	for _, issue := range issues {
+		rules = append(rules, issue.Rule+"@"+issue.Path)
+	}
+	return rules
+}
+
+func TestCheckAccessibility(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(root *Element)
+		want  []string
+	}{
+		{
+			name: "accessible page",
+			build: func(root *Element) {
+				html := root.Html().Attr("lang", "en")
+				body := html.Body()
+				body.H1().AddString("Title")
+				body.H2().AddString("Section")
+				body.Img().Attr("src", "/a.png").Attr("alt", "")
+				body.Button().Img().Attr("alt", "Close")
+				body.Label().AddString("Name").Input().Attr("name", "name")
+				body.Label().Attr("for", "email").AddString("Email")
+				body.Input().Attr("id", "email")
+				body.Textarea().Attr("aria-label", "Comment")
+				body.Input().Attr("type", "hidden")
+				body.Input().Attr("type", "submit")
+				body.Input().Attr("type", "button").Attr("value", "Go")
+			},
+		},
+		{
+			name:  "html lang",
+			build: func(root *Element) { root.Html().Attr("lang", " ") },
+			want:  []string{"html-lang@html"},
+		},
+		{
+			name: "image alt",
+			build: func(root *Element) {
+				div := root.Div()
+				div.Img().Attr("src", "/a.png")
+				div.Input().Attr("type", "image")
+				div.Img().Attr("title", "Logo")
+			},
+			want: []string{"img-alt@div > img[1]", "img-alt@div > input"},
+		},
+		{
+			name: "button name",
+			build: func(root *Element) {
+				nav := root.Nav()
+				nav.Button().Span()
+				nav.Input().Attr("type", "button")
+			},
+			want: []string{"button-name@nav > button", "button-name@nav > input"},
+		},
+		{
+			name: "control label",
+			build: func(root *Element) {
+				form := root.Form()
+				form.Input().Attr("id", "q")
+				form.Select()
+				form.Label().Attr("for", "other")
+			},
+			want: []string{"control-label@form > input#q", "control-label@form > select"},
+		},
+		{
+			name: "heading order",
+			build: func(root *Element) {
+				root.H1()
+				root.H3()
+				root.H2()
+				root.H4()
+			},
+			want: []string{"heading-order@h3", "heading-order@h4"},
+		},
+		{
+			name: "duplicate id reported once",
+			build: func(root *Element) {
+				root.P().Attr("id", "x")
+				root.Div().Span().Attr("id", "x")
+				root.P().Attr("id", "x")
+			},
+			want: []string{"duplicate-id@p#x"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(nil)
+			tt.build(g.Root)
+			if got := issueRules(CheckAccessibility(g.Root)); !slices.Equal(got, tt.want) {
+				t.Errorf("CheckAccessibility = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestA11yIssueError(t *testing.T) {
+	g := New(nil)
+	g.Root.Html()
+	issues := CheckAccessibility(g.Root)
+	if len(issues) != 1 {
+		t.Fatalf("issues = %v", issues)
+	}
+	if got, want := issues[0].Error(), "html: <html> has no lang attribute (html-lang)"; got != want {
+		t.Errorf("Error = %q, want %q", got, want)
+	}
+}
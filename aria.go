@@ -0,0 +1,437 @@
+package htmlsimple
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Role is a WAI-ARIA role, set with Element.Role.
+type Role string
+
+// The concrete roles of WAI-ARIA 1.2. Abstract roles such as widget or
+// landmark may not be used in markup and have no constant.
+const (
+	RoleAlert            Role = "alert"
+	RoleAlertDialog      Role = "alertdialog"
+	RoleApplication      Role = "application"
+	RoleArticle          Role = "article"
+	RoleBanner           Role = "banner"
+	RoleBlockquote       Role = "blockquote"
+	RoleButton           Role = "button"
+	RoleCaption          Role = "caption"
+	RoleCell             Role = "cell"
+	RoleCheckbox         Role = "checkbox"
+	RoleCode             Role = "code"
+	RoleColumnHeader     Role = "columnheader"
+	RoleComboBox         Role = "combobox"
+	RoleComplementary    Role = "complementary"
+	RoleContentInfo      Role = "contentinfo"
+	RoleDefinition       Role = "definition"
+	RoleDeletion         Role = "deletion"
+	RoleDialog           Role = "dialog"
+	RoleDocument         Role = "document"
+	RoleEmphasis         Role = "emphasis"
+	RoleFeed             Role = "feed"
+	RoleFigure           Role = "figure"
+	RoleForm             Role = "form"
+	RoleGeneric          Role = "generic"
+	RoleGrid             Role = "grid"
+	RoleGridCell         Role = "gridcell"
+	RoleGroup            Role = "group"
+	RoleHeading          Role = "heading"
+	RoleImg              Role = "img"
+	RoleInsertion        Role = "insertion"
+	RoleLink             Role = "link"
+	RoleList             Role = "list"
+	RoleListBox          Role = "listbox"
+	RoleListItem         Role = "listitem"
+	RoleLog              Role = "log"
+	RoleMain             Role = "main"
+	RoleMarquee          Role = "marquee"
+	RoleMath             Role = "math"
+	RoleMenu             Role = "menu"
+	RoleMenuBar          Role = "menubar"
+	RoleMenuItem         Role = "menuitem"
+	RoleMenuItemCheckbox Role = "menuitemcheckbox"
+	RoleMenuItemRadio    Role = "menuitemradio"
+	RoleMeter            Role = "meter"
+	RoleNavigation       Role = "navigation"
+	RoleNone             Role = "none"
+	RoleNote             Role = "note"
+	RoleOption           Role = "option"
+	RoleParagraph        Role = "paragraph"
+	RolePresentation     Role = "presentation"
+	RoleProgressBar      Role = "progressbar"
+	RoleRadio            Role = "radio"
+	RoleRadioGroup       Role = "radiogroup"
+	RoleRegion           Role = "region"
+	RoleRow              Role = "row"
+	RoleRowGroup         Role = "rowgroup"
+	RoleRowHeader        Role = "rowheader"
+	RoleScrollBar        Role = "scrollbar"
+	RoleSearch           Role = "search"
+	RoleSearchBox        Role = "searchbox"
+	RoleSeparator        Role = "separator"
+	RoleSlider           Role = "slider"
+	RoleSpinButton       Role = "spinbutton"
+	RoleStatus           Role = "status"
+	RoleStrong           Role = "strong"
+	RoleSubscript        Role = "subscript"
+	RoleSuperscript      Role = "superscript"
+	RoleSwitch           Role = "switch"
+	RoleTab              Role = "tab"
+	RoleTable            Role = "table"
+	RoleTabList          Role = "tablist"
+	RoleTabPanel         Role = "tabpanel"
+	RoleTerm             Role = "term"
+	RoleTextBox          Role = "textbox"
+	RoleTime             Role = "time"
+	RoleTimer            Role = "timer"
+	RoleToolbar          Role = "toolbar"
+	RoleTooltip          Role = "tooltip"
+	RoleTree             Role = "tree"
+	RoleTreeGrid         Role = "treegrid"
+	RoleTreeItem         Role = "treeitem"
+)
+
+// ariaRoles holds every Role constant.
+var ariaRoles = map[Role]bool{
+	RoleAlert:            true,
+	RoleAlertDialog:      true,
+	RoleApplication:      true,
+	RoleArticle:          true,
+	RoleBanner:           true,
+	RoleBlockquote:       true,
+	RoleButton:           true,
+	RoleCaption:          true,
+	RoleCell:             true,
+	RoleCheckbox:         true,
+	RoleCode:             true,
+	RoleColumnHeader:     true,
+	RoleComboBox:         true,
+	RoleComplementary:    true,
+	RoleContentInfo:      true,
+	RoleDefinition:       true,
+	RoleDeletion:         true,
+	RoleDialog:           true,
+	RoleDocument:         true,
+	RoleEmphasis:         true,
+	RoleFeed:             true,
+	RoleFigure:           true,
+	RoleForm:             true,
+	RoleGeneric:          true,
+	RoleGrid:             true,
+	RoleGridCell:         true,
+	RoleGroup:            true,
+	RoleHeading:          true,
+	RoleImg:              true,
+	RoleInsertion:        true,
+	RoleLink:             true,
+	RoleList:             true,
+	RoleListBox:          true,
+	RoleListItem:         true,
+	RoleLog:              true,
+	RoleMain:             true,
+	RoleMarquee:          true,
+	RoleMath:             true,
+	RoleMenu:             true,
+	RoleMenuBar:          true,
+	RoleMenuItem:         true,
+	RoleMenuItemCheckbox: true,
+	RoleMenuItemRadio:    true,
+	RoleMeter:            true,
+	RoleNavigation:       true,
+	RoleNone:             true,
+	RoleNote:             true,
+	RoleOption:           true,
+	RoleParagraph:        true,
+	RolePresentation:     true,
+	RoleProgressBar:      true,
+	RoleRadio:            true,
+	RoleRadioGroup:       true,
+	RoleRegion:           true,
+	RoleRow:              true,
+	RoleRowGroup:         true,
+	RoleRowHeader:        true,
+	RoleScrollBar:        true,
+	RoleSearch:           true,
+	RoleSearchBox:        true,
+	RoleSeparator:        true,
+	RoleSlider:           true,
+	RoleSpinButton:       true,
+	RoleStatus:           true,
+	RoleStrong:           true,
+	RoleSubscript:        true,
+	RoleSuperscript:      true,
+	RoleSwitch:           true,
+	RoleTab:              true,
+	RoleTable:            true,
+	RoleTabList:          true,
+	RoleTabPanel:         true,
+	RoleTerm:             true,
+	RoleTextBox:          true,
+	RoleTime:             true,
+	RoleTimer:            true,
+	RoleToolbar:          true,
+	RoleTooltip:          true,
+	RoleTree:             true,
+	RoleTreeGrid:         true,
+	RoleTreeItem:         true,
+}
+
+// ariaAttribute describes a WAI-ARIA state or property.
+type ariaAttribute struct {
+	// tokens, when set, are the only values allowed.
+	tokens []string
+	// list means the value is a space separated list of tokens.
+	list bool
+	// idrefs means the value references elements by id.
+	idrefs bool
+	// integer means the value is a whole number, number any number.
+	integer bool
+	number  bool
+	// roles are the roles that support the attribute. Global attributes,
+	// allowed on any element, have none.
+	roles []Role
+}
+
+var (
+	booleanTokens   = []string{"true", "false"}
+	tristateTokens  = []string{"true", "false", "mixed", "undefined"}
+	undefinedTokens = []string{"true", "false", "undefined"}
+
+	cellRoles  = []Role{RoleCell, RoleColumnHeader, RoleGridCell, RoleRowHeader}
+	gridRoles  = []Role{RoleGrid, RoleTable, RoleTreeGrid}
+	rangeRoles = []Role{RoleMeter, RoleProgressBar, RoleScrollBar, RoleSeparator, RoleSlider, RoleSpinButton}
+	setRoles   = []Role{RoleArticle, RoleListItem, RoleMenuItem, RoleMenuItemCheckbox, RoleMenuItemRadio, RoleOption, RoleRadio, RoleRow, RoleTab, RoleTreeItem}
+	textRoles  = []Role{RoleSearchBox, RoleTextBox}
+)
+
+// ariaAttributes are the states and properties of WAI-ARIA 1.2.
+var ariaAttributes = map[string]ariaAttribute{
+	"aria-activedescendant": {idrefs: true, roles: []Role{RoleApplication, RoleComboBox, RoleGrid, RoleGroup, RoleListBox, RoleMenu, RoleMenuBar, RoleRadioGroup, RoleRow, RoleSearchBox, RoleSpinButton, RoleTabList, RoleTextBox, RoleToolbar, RoleTree, RoleTreeGrid}},
+	"aria-atomic":           {tokens: booleanTokens},
+	"aria-autocomplete":     {tokens: []string{"inline", "list", "both", "none"}, roles: []Role{RoleComboBox, RoleSearchBox, RoleTextBox}},
+	"aria-busy":             {tokens: booleanTokens},
+	"aria-checked":          {tokens: tristateTokens, roles: []Role{RoleCheckbox, RoleMenuItemCheckbox, RoleMenuItemRadio, RoleOption, RoleRadio, RoleSwitch, RoleTreeItem}},
+	"aria-colcount":         {integer: true, roles: gridRoles},
+	"aria-colindex":         {integer: true, roles: append([]Role{RoleRow}, cellRoles...)},
+	"aria-colindextext":     {roles: append([]Role{RoleRow}, cellRoles...)},
+	"aria-colspan":          {integer: true, roles: cellRoles},
+	"aria-controls":         {idrefs: true},
+	"aria-current":          {tokens: []string{"page", "step", "location", "date", "time", "true", "false"}},
+	"aria-describedby":      {idrefs: true},
+	"aria-description":      {},
+	"aria-details":          {idrefs: true},
+	"aria-disabled":         {tokens: booleanTokens},
+	"aria-errormessage":     {idrefs: true},
+	"aria-expanded":         {tokens: undefinedTokens, roles: []Role{RoleApplication, RoleButton, RoleCheckbox, RoleColumnHeader, RoleComboBox, RoleGridCell, RoleLink, RoleListBox, RoleMenuItem, RoleMenuItemCheckbox, RoleMenuItemRadio, RoleRow, RoleRowHeader, RoleSwitch, RoleTab, RoleTreeItem}},
+	"aria-flowto":           {idrefs: true},
+	"aria-haspopup":         {tokens: []string{"false", "true", "menu", "listbox", "tree", "grid", "dialog"}},
+	"aria-hidden":           {tokens: undefinedTokens},
+	"aria-invalid":          {tokens: []string{"grammar", "false", "spelling", "true"}},
+	"aria-keyshortcuts":     {},
+	"aria-label":            {},
+	"aria-labelledby":       {idrefs: true},
+	"aria-level":            {integer: true, roles: []Role{RoleHeading, RoleListItem, RoleRow, RoleTreeItem}},
+	"aria-live":             {tokens: []string{"assertive", "off", "polite"}},
+	"aria-modal":            {tokens: booleanTokens, roles: []Role{RoleAlertDialog, RoleDialog}},
+	"aria-multiline":        {tokens: booleanTokens, roles: textRoles},
+	"aria-multiselectable":  {tokens: booleanTokens, roles: []Role{RoleGrid, RoleListBox, RoleTabList, RoleTree, RoleTreeGrid}},
+	"aria-orientation":      {tokens: []string{"horizontal", "undefined", "vertical"}, roles: []Role{RoleListBox, RoleMenu, RoleMenuBar, RoleRadioGroup, RoleScrollBar, RoleSeparator, RoleSlider, RoleTabList, RoleToolbar, RoleTree, RoleTreeGrid}},
+	"aria-owns":             {idrefs: true},
+	"aria-placeholder":      {roles: textRoles},
+	"aria-posinset":         {integer: true, roles: setRoles},
+	"aria-pressed":          {tokens: tristateTokens, roles: []Role{RoleButton}},
+	"aria-readonly":         {tokens: booleanTokens, roles: []Role{RoleCheckbox, RoleColumnHeader, RoleComboBox, RoleGrid, RoleGridCell, RoleListBox, RoleMenuItemCheckbox, RoleMenuItemRadio, RoleRadioGroup, RoleRowHeader, RoleSearchBox, RoleSlider, RoleSpinButton, RoleSwitch, RoleTextBox, RoleTreeGrid}},
+	"aria-relevant":         {tokens: []string{"additions", "all", "removals", "text"}, list: true},
+	"aria-required":         {tokens: booleanTokens, roles: []Role{RoleCheckbox, RoleColumnHeader, RoleComboBox, RoleGridCell, RoleListBox, RoleRadioGroup, RoleRowHeader, RoleSearchBox, RoleSpinButton, RoleSwitch, RoleTextBox, RoleTree, RoleTreeGrid}},
+	"aria-roledescription":  {},
+	"aria-rowcount":         {integer: true, roles: gridRoles},
+	"aria-rowindex":         {integer: true, roles: append([]Role{RoleRow}, cellRoles...)},
+	"aria-rowindextext":     {roles: append([]Role{RoleRow}, cellRoles...)},
+	"aria-rowspan":          {integer: true, roles: cellRoles},
+	"aria-selected":         {tokens: undefinedTokens, roles: []Role{RoleColumnHeader, RoleGridCell, RoleOption, RoleRow, RoleRowHeader, RoleTab, RoleTreeItem}},
+	"aria-setsize":          {integer: true, roles: setRoles},
+	"aria-sort":             {tokens: []string{"ascending", "descending", "none", "other"}, roles: []Role{RoleColumnHeader, RoleRowHeader}},
+	"aria-valuemax":         {number: true, roles: rangeRoles},
+	"aria-valuemin":         {number: true, roles: rangeRoles},
+	"aria-valuenow":         {number: true, roles: rangeRoles},
+	"aria-valuetext":        {roles: rangeRoles},
+}
+
+// implicitRole returns the role an HTML element has without a role
+// attribute, or "" when it has none or it depends on more than the element
+// itself.
+func implicitRole(e *Element) Role {
+	name := e.Tag.name()
+	switch name {
+	case "a", "area":
+		if _, ok := e.Attributes["href"]; ok {
+			return RoleLink
+		}
+		return RoleGeneric
+	case "input":
+		inputType, _ := attrValue(e, "type")
+		switch strings.ToLower(inputType) {
+		case "checkbox":
+			return RoleCheckbox
+		case "radio":
+			return RoleRadio
+		case "range":
+			return RoleSlider
+		case "number":
+			return RoleSpinButton
+		case "button", "image", "reset", "submit":
+			return RoleButton
+		case "search":
+			if _, ok := e.Attributes["list"]; !ok {
+				return RoleSearchBox
+			}
+		case "", "text", "email", "tel", "url":
+			if _, ok := e.Attributes["list"]; !ok {
+				return RoleTextBox
+			}
+		}
+		return ""
+	case "select":
+		if _, ok := e.Attributes["multiple"]; ok {
+			return RoleListBox
+		}
+		if size, _ := attrValue(e, "size"); size != "" && size != "0" && size != "1" {
+			return RoleListBox
+		}
+		return RoleComboBox
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		return RoleHeading
+	}
+	return implicitRoles[name]
+}
+
+// implicitRoles maps HTML elements whose implicit role only depends on the
+// element name to that role.
+var implicitRoles = map[string]Role{
+	"article": RoleArticle, "aside": RoleComplementary, "b": RoleGeneric,
+	"blockquote": RoleBlockquote, "button": RoleButton, "caption": RoleCaption,
+	"code": RoleCode, "del": RoleDeletion, "dfn": RoleTerm, "dialog": RoleDialog,
+	"div": RoleGeneric, "em": RoleEmphasis, "fieldset": RoleGroup,
+	"figure": RoleFigure, "hr": RoleSeparator, "i": RoleGeneric,
+	"ins": RoleInsertion, "li": RoleListItem, "main": RoleMain,
+	"math": RoleMath, "menu": RoleList, "meter": RoleMeter,
+	"nav": RoleNavigation, "ol": RoleList, "optgroup": RoleGroup,
+	"option": RoleOption, "output": RoleStatus, "p": RoleParagraph,
+	"progress": RoleProgressBar, "search": RoleSearch, "span": RoleGeneric,
+	"strong": RoleStrong, "sub": RoleSubscript, "sup": RoleSuperscript,
+	"table": RoleTable, "tbody": RoleRowGroup, "td": RoleCell,
+	"textarea": RoleTextBox, "tfoot": RoleRowGroup, "thead": RoleRowGroup,
+	"time": RoleTime, "tr": RoleRow, "ul": RoleList,
+}
+
+// Role sets the role attribute of the current element and returns the
+// current element. A role without a constant is still set, with a warning.
+//
+// Example:
+//
+//	element.Div().Role(htmlsimple.RoleDialog).AriaLabel("Settings")
+func (e *Element) Role(role Role) *Element {
+	if !ariaRoles[role] {
+		e.generator.report(e, SeverityWarning, "role %q is not a WAI-ARIA role", role)
+	}
+	return e.Attr("role", string(role))
+}
+
+// AriaLabel sets aria-label, the accessible name of the current element,
+// and returns the current element.
+func (e *Element) AriaLabel(label string) *Element {
+	return e.Attr("aria-label", label)
+}
+
+// AriaLabelledBy names the current element by the text of the elements with
+// ids and returns the current element.
+func (e *Element) AriaLabelledBy(ids ...string) *Element {
+	return e.Attr("aria-labelledby", strings.Join(ids, " "))
+}
+
+// AriaDescribedBy describes the current element by the text of the elements
+// with ids and returns the current element.
+func (e *Element) AriaDescribedBy(ids ...string) *Element {
+	return e.Attr("aria-describedby", strings.Join(ids, " "))
+}
+
+// AriaControls marks the elements with ids as controlled by the current
+// element and returns the current element.
+func (e *Element) AriaControls(ids ...string) *Element {
+	return e.Attr("aria-controls", strings.Join(ids, " "))
+}
+
+// AriaExpanded sets aria-expanded and returns the current element.
+func (e *Element) AriaExpanded(expanded bool) *Element {
+	return e.Attr("aria-expanded", strconv.FormatBool(expanded))
+}
+
+// AriaHidden sets aria-hidden and returns the current element.
+func (e *Element) AriaHidden(hidden bool) *Element {
+	return e.Attr("aria-hidden", strconv.FormatBool(hidden))
+}
+
+// AriaPressed sets aria-pressed and returns the current element.
+func (e *Element) AriaPressed(pressed bool) *Element {
+	return e.Attr("aria-pressed", strconv.FormatBool(pressed))
+}
+
+// AriaSelected sets aria-selected and returns the current element.
+func (e *Element) AriaSelected(selected bool) *Element {
+	return e.Attr("aria-selected", strconv.FormatBool(selected))
+}
+
+// AriaChecked sets aria-checked and returns the current element.
+func (e *Element) AriaChecked(checked bool) *Element {
+	return e.Attr("aria-checked", strconv.FormatBool(checked))
+}
+
+// AriaDisabled sets aria-disabled and returns the current element.
+func (e *Element) AriaDisabled(disabled bool) *Element {
+	return e.Attr("aria-disabled", strconv.FormatBool(disabled))
+}
+
+// AriaCurrent sets aria-current, such as "page" for the link to the current
+// page, and returns the current element.
+func (e *Element) AriaCurrent(value string) *Element {
+	return e.Attr("aria-current", value)
+}
+
+// AriaLive sets aria-live to "polite", "assertive" or "off" and returns the
+// current element.
+func (e *Element) AriaLive(value string) *Element {
+	return e.Attr("aria-live", value)
+}
+
+// Role sets the role attribute of the void element. See Element.Role.
+func (v *VoidElement) Role(role Role) *VoidElement {
+	v.element.Role(role)
+	return v
+}
+
+// AriaLabel sets aria-label on the void element.
+func (v *VoidElement) AriaLabel(label string) *VoidElement {
+	v.element.AriaLabel(label)
+	return v
+}
+
+// AriaLabelledBy names the void element by the elements with ids.
+func (v *VoidElement) AriaLabelledBy(ids ...string) *VoidElement {
+	v.element.AriaLabelledBy(ids...)
+	return v
+}
+
+// AriaDescribedBy describes the void element by the elements with ids.
+func (v *VoidElement) AriaDescribedBy(ids ...string) *VoidElement {
+	v.element.AriaDescribedBy(ids...)
+	return v
+}
+
+// AriaHidden sets aria-hidden on the void element.
+func (v *VoidElement) AriaHidden(hidden bool) *VoidElement {
+	v.element.AriaHidden(hidden)
+	return v
+}
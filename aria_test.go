@@ -0,0 +1,163 @@
+package htmlsimple
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAriaHelpers(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(root *Element)
+		want  string
+		warns bool
+	}{
+		{
+			name: "dialog",
+			build: func(root *Element) {
+				root.Div().Role(RoleDialog).AriaLabel("Settings").AriaHidden(false)
+			},
+			want: `<div aria-hidden="false" aria-label="Settings" role="dialog"></div>`,
+		},
+		{
+			name: "disclosure button",
+			build: func(root *Element) {
+				root.Button().AriaExpanded(true).AriaControls("menu", "sub").AriaPressed(false)
+			},
+			want: `<button aria-controls="menu sub" aria-expanded="true" aria-pressed="false"></button>`,
+		},
+		{
+			name: "references",
+			build: func(root *Element) {
+				root.Section().AriaLabelledBy("t1", "t2").AriaDescribedBy("d")
+			},
+			want: `<section aria-describedby="d" aria-labelledby="t1 t2"></section>`,
+		},
+		{
+			name: "states",
+			build: func(root *Element) {
+				root.Li().Role(RoleOption).AriaSelected(true).AriaChecked(false).AriaDisabled(true)
+				root.A().AriaCurrent("page")
+				root.P().AriaLive("polite")
+			},
+			want: `<li aria-checked="false" aria-disabled="true" aria-selected="true" role="option"></li><a aria-current="page"></a><p aria-live="polite"></p>`,
+		},
+		{
+			name: "void element",
+			build: func(root *Element) {
+				root.Img().Role(RolePresentation).AriaHidden(true).AriaLabel("x").AriaLabelledBy("a").AriaDescribedBy("b")
+			},
+			want: `<img aria-describedby="b" aria-hidden="true" aria-label="x" aria-labelledby="a" role="presentation" />`,
+		},
+		{
+			name: "unknown role is set with a warning",
+			build: func(root *Element) {
+				root.Div().Role("fancy")
+			},
+			want:  `<div role="fancy"></div>`,
+			warns: true,
+		},
+		{
+			name: "aria attributes on svg",
+			build: func(root *Element) {
+				root.Svg().Role(RoleImg).AriaLabel("Logo")
+			},
+			want: `<svg aria-label="Logo" role="img" />`,
+		},
+		{
+			name: "unknown aria attribute",
+			build: func(root *Element) {
+				root.Div().Attr("aria-fancy", "x")
+			},
+			want:  `<div data-aria-fancy="x"></div>`,
+			warns: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(nil)
+			tt.build(g.Root)
+			if got := g.Generate(); got != tt.want {
+				t.Errorf("Generate = %q, want %q", got, tt.want)
+			}
+			if warns := len(g.Diagnostics()) > 0; warns != tt.warns {
+				t.Errorf("diagnostics = %v, want warning %v", g.Diagnostics(), tt.warns)
+			}
+		})
+	}
+}
+
+func TestAriaChecks(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(root *Element)
+		want  []string
+	}{
+		{
+			name: "valid",
+			build: func(root *Element) {
+				root.H2().Attr("id", "t")
+				root.Div().Role(RoleTabList).Div().Role(RoleTab).AriaSelected(true).AriaControls("t")
+				root.Select().AriaExpanded(false).Attr("aria-label", "Pick")
+				root.Div().Role("none presentation")
+				root.H3().Attr("aria-level", "3")
+			},
+		},
+		{
+			name: "unknown role in a list",
+			build: func(root *Element) {
+				root.Div().Attr("role", "fancy button")
+			},
+			want: []string{"aria-role@div"},
+		},
+		{
+			name: "missing reference",
+			build: func(root *Element) {
+				root.Div().AriaLabelledBy("missing")
+			},
+			want: []string{"aria-attribute@div"},
+		},
+		{
+			name: "invalid values",
+			build: func(root *Element) {
+				root.Div().Role(RoleSlider).Attr("aria-valuenow", "ten")
+				root.Span().AriaLive("loud")
+				root.H1().Attr("aria-level", "1.5")
+			},
+			want: []string{"aria-attribute@div", "aria-attribute@span", "aria-attribute@h1"},
+		},
+		{
+			name: "not supported by explicit role",
+			build: func(root *Element) {
+				root.Div().Role(RoleDialog).AriaChecked(true)
+			},
+			want: []string{"aria-allowed@div"},
+		},
+		{
+			name: "not supported by implicit role",
+			build: func(root *Element) {
+				root.A().Attr("href", "/").AriaChecked(true)
+				root.Input().Attr("type", "checkbox").Attr("aria-checked", "true")
+				root.P().Attr("aria-sort", "ascending")
+			},
+			want: []string{"aria-allowed@a", "aria-allowed@p"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(nil)
+			tt.build(g.Root)
+			var got []string
+			for _, issue := range CheckAccessibility(g.Root) {
+				if issue.Rule != "control-label" && issue.Rule != "duplicate-id" {
+					got = append(got, issue.Rule+"@"+issue.Path)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("CheckAccessibility = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package htmlsimple_test
+
+import (
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/PatrickVerhagen/htmlsimple"
+)
+
+// The benchmarks build and render a typical page and report allocations
+// per rendered node, so changes to the element representation or the
+// renderer can be compared:
+//
+//	go test -run '^$' -bench . -benchmem
+
+// benchRows is the number of table rows on the page.
+const benchRows = 50
+
+// buildPage builds a page with the usual parts: head, navigation, an
+// article, a table and a footer.
+func buildPage(g *htmlsimple.Generator) *htmlsimple.Generator {
+	page := g.Root.Html().Attr("lang", "en")
+
+	head := page.Head()
+	head.Meta().Attr("charset", "utf-8")
+	head.Title().AddString("Orders")
+	head.Link().WithAttrs(htmlsimple.KV("rel", "stylesheet"), htmlsimple.KV("href", "/static/site.css"))
+
+	body := page.Body().Attr("class", "layout")
+	nav := body.Nav().Attr("class", "main-nav").Ul()
+	for _, item := range []string{"Home", "Orders", "Customers", "Products", "Reports", "Settings"} {
+		nav.Li().A().Attr("href", "/"+item).AddString(item)
+	}
+
+	article := body.Main().Article()
+	article.H1().AddString("Orders")
+	for i := 0; i < 3; i++ {
+		p := article.P().AddString("Orders placed in the last 30 days, ")
+		p.Strong().AddString("newest first")
+		p.AddString(". Click an order to see its details.")
+	}
+
+	table := article.Table().Attr("class", "orders")
+	header := table.Thead().Tr()
+	for _, h := range []string{"Order", "Customer", "Total", "Status"} {
+		header.Th().Attr("scope", "col").AddString(h)
+	}
+	tbody := table.Tbody()
+	for i := 0; i < benchRows; i++ {
+		tr := tbody.Tr().Attr("id", "order-"+strconv.Itoa(i))
+		tr.Td().A().Attr("href", "/orders/"+strconv.Itoa(i)).AddString("#" + strconv.Itoa(i))
+		tr.Td().AddString("Customer & Co")
+		tr.Td().Attr("class", "number").AddString(strconv.Itoa(i*17) + ".00")
+		tr.Td().AddString("shipped")
+	}
+
+	body.Footer().P().AddString("© Example Ltd")
+	return g
+}
+
+func countNodes(e *htmlsimple.Element) int {
+	n := 1
+	for _, c := range e.Children {
+		if el, ok := c.(*htmlsimple.Element); ok {
+			n += countNodes(el)
+		}
+	}
+	return n
+}
+
+// benchPage runs fn b.N times and reports its allocations per node of the
+// typical page.
+func benchPage(b *testing.B, fn func()) {
+	nodes := countNodes(buildPage(htmlsimple.New(nil)).Root)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fn()
+	}
+	b.StopTimer()
+
+	b.ReportMetric(testing.AllocsPerRun(1, fn)/float64(nodes), "allocs/node")
+}
+
+func BenchmarkBuild(b *testing.B) {
+	benchPage(b, func() {
+		buildPage(htmlsimple.New(nil))
+	})
+}
+
+func BenchmarkBuildReleased(b *testing.B) {
+	benchPage(b, func() {
+		buildPage(htmlsimple.New(nil)).Release()
+	})
+}
+
+func BenchmarkRender(b *testing.B) {
+	page := buildPage(htmlsimple.New(nil))
+	benchPage(b, func() {
+		page.Generate()
+	})
+}
+
+func BenchmarkRenderTo(b *testing.B) {
+	page := buildPage(htmlsimple.New(nil))
+	benchPage(b, func() {
+		page.Root.RenderTo(io.Discard, htmlsimple.RenderOptions{})
+	})
+}
+
+func BenchmarkRenderToMinified(b *testing.B) {
+	page := buildPage(htmlsimple.New(nil))
+	benchPage(b, func() {
+		page.Root.RenderTo(io.Discard, htmlsimple.RenderOptions{Minify: true})
+	})
+}
+
+func BenchmarkBuildRenderToReleased(b *testing.B) {
+	benchPage(b, func() {
+		g := buildPage(htmlsimple.New(nil))
+		g.Root.RenderTo(io.Discard, htmlsimple.RenderOptions{})
+		g.Release()
+	})
+}
@@ -0,0 +1,120 @@
+package htmlsimple
+
+import (
+	"html"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// appendClasses adds the classes in value to e, skipping those it already
+// has, so the order in which classes were first added is kept. With a
+// ClassMerger set, a class added again moves to the end instead and the
+// merger resolves conflicts.
+func (e *Element) appendClasses(value string) {
+	classes := e.Attributes["class"]
+	merger := e.generator.classMerger
+	for _, class := range strings.Fields(value) {
+		if !slices.Contains(classes, class) {
+			classes = append(classes, class)
+		} else if merger != nil {
+			classes = append(slices.DeleteFunc(classes, func(c string) bool { return c == class }), class)
+		}
+	}
+	if merger != nil {
+		classes = merger.MergeClasses(classes)
+	}
+	e.Attributes["class"] = classes
+}
+
+// AddClass adds classes to the current element and returns the current
+// element. Classes it already has are not repeated.
+//
+// Example:
+//
+//	element.Button().AddClass("btn", "btn-primary")
+func (e *Element) AddClass(classes ...string) *Element {
+	return e.Attr("class", strings.Join(classes, " "))
+}
+
+// RemoveClass removes classes from the current element and returns the
+// current element. The class attribute is dropped once it is empty.
+func (e *Element) RemoveClass(classes ...string) *Element {
+	current := e.Attributes["class"]
+	for _, class := range classes {
+		escaped := html.EscapeString(class)
+		current = slices.DeleteFunc(current, func(c string) bool { return c == escaped })
+	}
+	if len(current) == 0 {
+		delete(e.Attributes, "class")
+	} else {
+		e.Attributes["class"] = current
+	}
+	return e
+}
+
+// ToggleClass adds class to the current element when on is true and
+// removes it otherwise. It returns the current element.
+//
+// Example:
+//
+//	li.ToggleClass("active", item.ID == currentID)
+func (e *Element) ToggleClass(class string, on bool) *Element {
+	if on {
+		return e.AddClass(class)
+	}
+	return e.RemoveClass(class)
+}
+
+// HasClass reports whether the current element has class.
+func (e *Element) HasClass(class string) bool {
+	return slices.Contains(e.Attributes["class"], html.EscapeString(class))
+}
+
+// Classes toggles every class in classes according to its value, adding
+// the enabled ones in sorted order, and returns the current element.
+//
+// Example:
+//
+//	element.Div().Classes(map[string]bool{"card": true, "selected": selected})
+func (e *Element) Classes(classes map[string]bool) *Element {
+	names := make([]string, 0, len(classes))
+	for name := range classes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		e.ToggleClass(name, classes[name])
+	}
+	return e
+}
+
+// AddClass adds classes to the void element. See Element.AddClass.
+func (v *VoidElement) AddClass(classes ...string) *VoidElement {
+	v.element.AddClass(classes...)
+	return v
+}
+
+// RemoveClass removes classes from the void element.
+func (v *VoidElement) RemoveClass(classes ...string) *VoidElement {
+	v.element.RemoveClass(classes...)
+	return v
+}
+
+// ToggleClass adds or removes class on the void element. See
+// Element.ToggleClass.
+func (v *VoidElement) ToggleClass(class string, on bool) *VoidElement {
+	v.element.ToggleClass(class, on)
+	return v
+}
+
+// HasClass reports whether the void element has class.
+func (v *VoidElement) HasClass(class string) bool {
+	return v.element.HasClass(class)
+}
+
+// Classes toggles the classes of the void element. See Element.Classes.
+func (v *VoidElement) Classes(classes map[string]bool) *VoidElement {
+	v.element.Classes(classes)
+	return v
+}
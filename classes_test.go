@@ -0,0 +1,125 @@
+package htmlsimple
+
+import "testing"
+
+func TestClasses(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(div *Element)
+		want  string
+	}{
+		{
+			name:  "add",
+			build: func(div *Element) { div.AddClass("btn", "btn-primary") },
+			want:  `<div class="btn btn-primary"></div>`,
+		},
+		{
+			name:  "add keeps first order",
+			build: func(div *Element) { div.AddClass("a", "b").AddClass("b a c") },
+			want:  `<div class="a b c"></div>`,
+		},
+		{
+			name:  "attr skips duplicates",
+			build: func(div *Element) { div.Attr("class", "a b a").Attr("class", "b") },
+			want:  `<div class="a b"></div>`,
+		},
+		{
+			name:  "remove",
+			build: func(div *Element) { div.AddClass("a", "b", "c").RemoveClass("b", "missing") },
+			want:  `<div class="a c"></div>`,
+		},
+		{
+			name:  "remove last drops attribute",
+			build: func(div *Element) { div.AddClass("a").RemoveClass("a") },
+			want:  `<div></div>`,
+		},
+		{
+			name:  "remove escaped",
+			build: func(div *Element) { div.AddClass("a&b", "c").RemoveClass("a&b") },
+			want:  `<div class="c"></div>`,
+		},
+		{
+			name: "toggle",
+			build: func(div *Element) {
+				div.ToggleClass("on", true).ToggleClass("off", false).ToggleClass("gone", true).ToggleClass("gone", false)
+			},
+			want: `<div class="on"></div>`,
+		},
+		{
+			name: "classes map sorted",
+			build: func(div *Element) {
+				div.AddClass("gone").Classes(map[string]bool{"zeta": true, "alpha": true, "gone": false})
+			},
+			want: `<div class="alpha zeta"></div>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(nil)
+			tt.build(g.Root.Div())
+			if got := g.Generate(); got != tt.want {
+				t.Errorf("Generate = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasClass(t *testing.T) {
+	tests := []struct {
+		class string
+		want  bool
+	}{
+		{"btn", true},
+		{"a&b", true},
+		{"bt", false},
+		{"btn primary", false},
+	}
+
+	div := New(nil).Root.Div().AddClass("btn", "a&b")
+	input := New(nil).Root.Input().AddClass("btn", "a&b")
+	for _, tt := range tests {
+		t.Run(tt.class, func(t *testing.T) {
+			if got := div.HasClass(tt.class); got != tt.want {
+				t.Errorf("HasClass(%q) = %v, want %v", tt.class, got, tt.want)
+			}
+			if got := input.HasClass(tt.class); got != tt.want {
+				t.Errorf("void HasClass(%q) = %v, want %v", tt.class, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVoidClasses(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(input *VoidElement)
+		want  string
+	}{
+		{
+			name:  "add and remove",
+			build: func(input *VoidElement) { input.AddClass("a", "b", "c").RemoveClass("b") },
+			want:  `<input class="a c" />`,
+		},
+		{
+			name:  "toggle",
+			build: func(input *VoidElement) { input.ToggleClass("on", true).ToggleClass("off", false) },
+			want:  `<input class="on" />`,
+		},
+		{
+			name:  "classes map",
+			build: func(input *VoidElement) { input.Classes(map[string]bool{"b": true, "a": true, "c": false}) },
+			want:  `<input class="a b" />`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(nil)
+			tt.build(g.Root.Input())
+			if got := g.Generate(); got != tt.want {
+				t.Errorf("Generate = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSplitList(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"", nil},
+		{"a", []string{"a"}},
+		{"a, b ,,c", []string{"a", "b", "c"}},
+		{" , ", nil},
+	}
+	for _, tt := range tests {
+		if got := splitList(tt.in); !slices.Equal(got, tt.want) {
+			t.Errorf("splitList(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateSubsets(t *testing.T) {
+	elements, err := loadSpec([]byte(`{"version": 1, "elements": [
+		{"name": "p", "categories": ["flow"]},
+		{"name": "em", "categories": ["flow", "phrasing"]},
+		{"name": "br", "void": true, "categories": ["flow", "phrasing"]},
+		{"name": "circle", "namespace": "svg"}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		cfg    config
+		want   []string
+		absent []string
+	}{
+		{
+			name: "all",
+			cfg:  config{pkg: "htmlsimple"},
+			want: []string{"func (e *Element) P()", "func (e *Element) Em()", "func (e *Element) Br()", "func (e *Element) SvgCircle()", "var elementCategories"},
+		},
+		{
+			name:   "categories",
+			cfg:    config{pkg: "htmlsimple", categories: []string{"phrasing"}},
+			want:   []string{"func (e *Element) Em()", "func (e *Element) Br()"},
+			absent: []string{"func (e *Element) P()", "SvgCircle"},
+		},
+		{
+			name:   "elements",
+			cfg:    config{pkg: "htmlsimple", elements: []string{"p", "circle"}},
+			want:   []string{"func (e *Element) P()", "func (e *Element) SvgCircle()"},
+			absent: []string{"Em()", "Br()"},
+		},
+		{
+			name:   "elements within categories",
+			cfg:    config{pkg: "htmlsimple", categories: []string{"phrasing"}, elements: []string{"em", "p"}},
+			want:   []string{"func (e *Element) Em()"},
+			absent: []string{"P()", "Br()"},
+		},
+		{
+			name: "prefix",
+			cfg:  config{pkg: "htmlsimple", prefix: "New", elements: []string{"p"}},
+			want: []string{"func (e *Element) NewP() *Element"},
+		},
+		{
+			name: "external package",
+			cfg:  config{pkg: "phrasing", categories: []string{"phrasing"}},
+			want: []string{
+				"package phrasing",
+				`import "github.com/PatrickVerhagen/htmlsimple"`,
+				"func Wrap(e *htmlsimple.Element) Element",
+				"func (e Element) Em() Element {\n\treturn Element{el: e.el.Add(htmlsimple.NormalTag(\"em\"))}",
+				"func (e Element) Br() *htmlsimple.VoidElement {\n\treturn e.el.AddVoid(htmlsimple.VoidTag(\"br\"))",
+			},
+			absent: []string{"elementCategories", "elementAttributes"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src, err := generate(tt.cfg, elements)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := parser.ParseFile(token.NewFileSet(), "tags_gen.go", src, 0); err != nil {
+				t.Fatalf("generated source does not parse: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(src), want) {
+					t.Errorf("generated source is missing %q", want)
+				}
+			}
+			for _, absent := range tt.absent {
+				if strings.Contains(string(src), absent) {
+					t.Errorf("generated source contains %q", absent)
+				}
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	dir := t.TempDir()
+	current := filepath.Join(dir, "current.go")
+	stale := filepath.Join(dir, "stale.go")
+	for path, content := range map[string]string{current: "package a\n", stale: "package b\n"} {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr string
+	}{
+		{"up to date", current, ""},
+		{"stale", stale, "is out of date"},
+		{"missing", filepath.Join(dir, "missing.go"), "is missing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := check(tt.path, []byte("package a\n"))
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("unexpected error %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("check error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
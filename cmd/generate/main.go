@@ -2,88 +2,417 @@ package main
 
 import (
 	"bytes"
+	_ "embed"
+	"encoding/json"
+	"flag"
 	"fmt"
 	"go/format"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 )
 
-var htmlTags = []string{"a", "abbr", "acronym", "address", "area", "article", "aside", "audio", "b", "base", "bdi", "bdo", "big", "blockquote", "body", "br", "button", "canvas", "caption", "center", "cite", "code", "col", "colgroup", "data", "datalist", "dd", "del", "details", "dfn", "dialog", "dir", "div", "dl", "dt", "em", "embed", "fencedframe", "fieldset", "figcaption", "figure", "font", "footer", "form", "frame", "frameset", "h1", "head", "header", "hgroup", "hr", "html", "i", "iframe", "img", "input", "ins", "kbd", "label", "legend", "li", "link", "main", "map", "mark", "marquee", "math", "menu", "meta", "meter", "nav", "nobr", "noembed", "noframes", "noscript", "object", "ol", "optgroup", "option", "output", "p", "param", "picture", "plaintext", "portal", "pre", "progress", "q", "rb", "rp", "rt", "rtc", "ruby", "s", "samp", "script", "search", "section", "select", "slot", "small", "source", "span", "strike", "strong", "style", "sub", "summary", "sup", "svg", "table", "tbody", "td", "template", "textarea", "tfoot", "th", "thead", "time", "title", "tr", "track", "tt", "u", "ul", "var", "video", "wbr", "xmp","script"}
-
-// List of void elements in HTML5
-var voidElements = map[string]bool{
-	"area":   true,
-	"base":   true,
-	"br":     true,
-	"col":    true,
-	"embed":  true,
-	"hr":     true,
-	"img":    true,
-	"input":  true,
-	"link":   true,
-	"meta":   true,
-	"param":  true,
-	"source": true,
-	"track":  true,
-	"wbr":    true,
+// The element table the builders are generated from. Adding an element or
+// attribute is a change to this file followed by go generate.
+//
+//go:embed elements.json
+var elementsJSON []byte
+
+// specVersion is the version of the elements.json layout this generator
+// understands.
+const specVersion = 1
+
+// spec is the layout of elements.json.
+type spec struct {
+	Version  int       `json:"version"`
+	Elements []element `json:"elements"`
+}
+
+// element describes a single element in elements.json.
+type element struct {
+	Name string `json:"name"`
+	// Namespace is "html" (the default), "svg" or "math".
+	Namespace string `json:"namespace"`
+	// Void elements have no content and no end tag. Only valid for HTML.
+	Void bool `json:"void"`
+	// Categories are the content categories of the element, such as flow
+	// and phrasing, as defined by the HTML spec.
+	Categories []string `json:"categories"`
+	// Attributes lists attributes specific to the element, on top of the
+	// global attributes.
+	Attributes []string `json:"attributes"`
+	// Deprecated, when set, explains what to use instead.
+	Deprecated string `json:"deprecated"`
+	Doc        string `json:"doc"`
+}
+
+// importPath is the package the builders of an external package wrap.
+const importPath = "github.com/PatrickVerhagen/htmlsimple"
+
+// config holds the command line options.
+type config struct {
+	output     string
+	pkg        string
+	categories []string
+	elements   []string
+	prefix     string
+	check      bool
 }
 
 func main() {
-	// Find the package root directory
+	var cfg config
+	var categoryList, elementList string
+	flag.StringVar(&cfg.output, "o", "tags_gen.go", "output file")
+	flag.StringVar(&cfg.pkg, "pkg", "htmlsimple", "package name of the generated file")
+	flag.StringVar(&categoryList, "categories", "", "comma-separated content categories to generate, e.g. phrasing (default all)")
+	flag.StringVar(&elementList, "elements", "", "comma-separated element names to generate (default all)")
+	flag.StringVar(&cfg.prefix, "prefix", "", "prefix for every generated method name")
+	flag.BoolVar(&cfg.check, "check", false, "exit non-zero if the output file is not up to date instead of writing it")
+	flag.Parse()
+	cfg.categories = splitList(categoryList)
+	cfg.elements = splitList(elementList)
+
+	// The generator should be run from the package root via go:generate
 	dir, err := os.Getwd()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error getting working directory: %v\n", err)
 		os.Exit(1)
 	}
+	outputPath := cfg.output
+	if !filepath.IsAbs(outputPath) {
+		outputPath = filepath.Join(dir, outputPath)
+	}
 
-	// The generator should be run from the package root via go:generate
-	outputPath := filepath.Join(dir, "tags_gen.go")
+	elements, err := loadSpec(elementsJSON)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading element table: %v\n", err)
+		os.Exit(1)
+	}
+
+	formattedBytes, err := generate(cfg, elements)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error formatting code: %v\n", err)
+		os.Exit(1)
+	}
+
+	if cfg.check {
+		if err := check(outputPath, formattedBytes); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Write the generated file
+	err = os.WriteFile(outputPath, formattedBytes, 0644)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing file: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Successfully generated %s\n", outputPath)
+}
+
+// check returns an error unless the file at path holds exactly want.
+func check(path string, want []byte) error {
+	existing, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("%s is missing, run go generate: %w", path, err)
+	}
+	if !bytes.Equal(existing, want) {
+		return fmt.Errorf("%s is out of date, run go generate", path)
+	}
+	return nil
+}
+
+// generate returns the formatted source for the elements selected by cfg.
+// Builders for the htmlsimple package itself are methods on Element; any
+// other package gets a wrapper type exposing only the selected builders.
+func generate(cfg config, elements []element) ([]byte, error) {
+	external := cfg.pkg != "htmlsimple"
 
 	var buf bytes.Buffer
 
 	// Write package and imports
-	buf.WriteString(`// Code generated by htmlsimple generator; DO NOT EDIT.
+	fmt.Fprintf(&buf, `// Code generated by htmlsimple generator; DO NOT EDIT.
+
+package %s
+`, cfg.pkg)
+	if external {
+		fmt.Fprintf(&buf, `
+import "%s"
+
+// Element wraps an htmlsimple.Element and only exposes the builders
+// generated for this package.
+type Element struct {
+	el *htmlsimple.Element
+}
+
+// Wrap restricts e to the builders of this package.
+func Wrap(e *htmlsimple.Element) Element {
+	return Element{el: e}
+}
+
+// Unwrap returns the underlying htmlsimple.Element.
+func (e Element) Unwrap() *htmlsimple.Element {
+	return e.el
+}
+
+// Attr sets a single attribute, see htmlsimple.Element.Attr.
+func (e Element) Attr(key, value string) Element {
+	e.el.Attr(key, value)
+	return e
+}
+
+// WithAttrs sets multiple attributes, see htmlsimple.Element.WithAttrs.
+func (e Element) WithAttrs(attrs ...htmlsimple.KeyValue) Element {
+	e.el.WithAttrs(attrs...)
+	return e
+}
 
-package htmlsimple
+// AddString adds sanitized text content to the element.
+func (e Element) AddString(content string) Element {
+	e.el.AddString(content)
+	return e
+}
+`, importPath)
+	}
 
-// Tag methods for HTML elements
+	buf.WriteString(`
+// Tag methods for HTML, SVG and MathML elements
 `)
 
 	// Write methods for each tag
-	for _, tag := range htmlTags {
-		methodName := strings.Title(tag) // Capitalize first letter for method name
-
-		if voidElements[tag] {
-			fmt.Fprintf(&buf, `
-// %s creates a void <%s> element and adds it to the current element.
-func (e *Element) %s() *Element {
-    return e.AddVoid(VoidTag("%s"))
-}
-`, methodName, tag, methodName, tag)
-		} else {
-			fmt.Fprintf(&buf, `
-// %s creates a <%s> element and adds it to the current element.
-func (e *Element) %s() *Element {
-    return e.Add(NormalTag("%s"))
-}
-`, methodName, tag, methodName, tag)
+	for _, el := range elements {
+		if cfg.selects(el) {
+			writeMethod(&buf, el, cfg.prefix, external)
 		}
 	}
 
+	if !external {
+		writeCategories(&buf, elements)
+		writeAttributes(&buf, elements)
+	}
+
 	// Format the generated code
-	formattedBytes, err := format.Source(buf.Bytes())
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error formatting code: %v\n", err)
-		os.Exit(1)
+	return format.Source(buf.Bytes())
+}
+
+// selects reports whether el is part of the requested subset.
+func (cfg config) selects(el element) bool {
+	if len(cfg.elements) > 0 && !contains(cfg.elements, el.Name) {
+		return false
+	}
+	if len(cfg.categories) == 0 {
+		return true
+	}
+	for _, category := range el.Categories {
+		if contains(cfg.categories, category) {
+			return true
+		}
 	}
+	return false
+}
 
-	// Write to tags_gen.go
-	err = os.WriteFile(outputPath, formattedBytes, 0644)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error writing file: %v\n", err)
-		os.Exit(1)
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
 	}
+	return false
+}
 
-	fmt.Printf("Successfully generated %s\n", outputPath)
+// splitList splits a comma-separated flag value, ignoring empty entries.
+func splitList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// loadSpec parses and validates the element table.
+func loadSpec(data []byte) ([]element, error) {
+	var s spec
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	if s.Version != specVersion {
+		return nil, fmt.Errorf("unsupported version %d, want %d", s.Version, specVersion)
+	}
+
+	methods := make(map[string]string)
+	for i, el := range s.Elements {
+		if el.Name == "" {
+			return nil, fmt.Errorf("element %d has no name", i)
+		}
+		switch el.Namespace {
+		case "":
+			s.Elements[i].Namespace = "html"
+		case "html", "svg", "math":
+		default:
+			return nil, fmt.Errorf("%s: unknown namespace %q", el.Name, el.Namespace)
+		}
+		if el.Void && s.Elements[i].Namespace != "html" {
+			return nil, fmt.Errorf("%s: only HTML elements can be void", el.Name)
+		}
+
+		method := methodName(s.Elements[i])
+		if other, exists := methods[method]; exists {
+			return nil, fmt.Errorf("%s: method %s is already generated for %s", el.Name, method, other)
+		}
+		methods[method] = el.Name
+	}
+	return s.Elements, nil
+}
+
+// methodName returns the builder method name for el. SVG elements get an
+// Svg prefix because several of them (a, title, text) clash with HTML tags
+// or read ambiguously on their own; hyphenated names become CamelCase.
+func methodName(el element) string {
+	switch {
+	case el.Namespace == "svg" && el.Name == "svg":
+		return "Svg"
+	case el.Namespace == "svg":
+		return "Svg" + camelCase(el.Name)
+	}
+	return camelCase(el.Name)
+}
+
+func writeMethod(buf *bytes.Buffer, el element, prefix string, external bool) {
+	method := prefix + methodName(el)
+
+	kind, call, result := "", "", "Element"
+	switch {
+	case el.Namespace == "svg":
+		kind, call = "an SVG", fmt.Sprintf("AddSvg(SvgTag(%q))", el.Name)
+	case el.Namespace == "math":
+		kind, call = "a MathML", fmt.Sprintf("AddMath(MathTag(%q))", el.Name)
+	case el.Void:
+		kind, call, result = "a void", fmt.Sprintf("AddVoid(VoidTag(%q))", el.Name), "VoidElement"
+	default:
+		kind, call = "a", fmt.Sprintf("Add(NormalTag(%q))", el.Name)
+	}
+
+	fmt.Fprintf(buf, "\n// %s creates %s <%s> element and adds it to the current element.\n", method, kind, el.Name)
+	writeComment(buf, el.Doc)
+	if len(el.Attributes) > 0 {
+		buf.WriteString("//\n")
+		writeComment(buf, "Element-specific attributes: "+strings.Join(el.Attributes, ", ")+".")
+	}
+	if el.Deprecated != "" {
+		buf.WriteString("//\n")
+		writeComment(buf, "Deprecated: "+el.Deprecated)
+	}
+	switch {
+	case external && result == "VoidElement":
+		fmt.Fprintf(buf, `func (e Element) %s() *htmlsimple.VoidElement {
+    return e.el.%s
+}
+`, method, strings.Replace(call, "(", "(htmlsimple.", 1))
+	case external:
+		fmt.Fprintf(buf, `func (e Element) %s() Element {
+    return Element{el: e.el.%s}
+}
+`, method, strings.Replace(call, "(", "(htmlsimple.", 1))
+	default:
+		fmt.Fprintf(buf, `func (e *Element) %s() *%s {
+    return e.%s
+}
+`, method, result, call)
+	}
+}
+
+// writeCategories writes the content categories of every element that
+// has any, so rendering can tell inline content from blocks.
+func writeCategories(buf *bytes.Buffer, elements []element) {
+	buf.WriteString(`
+// elementCategories lists the content categories of each element, keyed by
+// tag name.
+var elementCategories = map[string][]string{
+`)
+	var names []string
+	categories := make(map[string][]string)
+	for _, el := range elements {
+		if len(el.Categories) == 0 {
+			continue
+		}
+		names = append(names, el.Name)
+		categories[el.Name] = el.Categories
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(buf, "%q: {", name)
+		for i, category := range categories[name] {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			fmt.Fprintf(buf, "%q", category)
+		}
+		buf.WriteString("},\n")
+	}
+	buf.WriteString("}\n")
+}
+
+// writeAttributes writes the element-specific attributes of every HTML
+// element that has any, which the attribute policy allows on that element
+// only.
+func writeAttributes(buf *bytes.Buffer, elements []element) {
+	buf.WriteString(`
+// elementAttributes lists the attributes specific to each HTML element,
+// keyed by tag name.
+var elementAttributes = map[string][]string{
+`)
+	var names []string
+	attributes := make(map[string][]string)
+	for _, el := range elements {
+		if el.Namespace != "html" || len(el.Attributes) == 0 {
+			continue
+		}
+		names = append(names, el.Name)
+		attributes[el.Name] = el.Attributes
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(buf, "%q: {", name)
+		for i, attribute := range attributes[name] {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			fmt.Fprintf(buf, "%q", attribute)
+		}
+		buf.WriteString("},\n")
+	}
+	buf.WriteString("}\n")
+}
+
+// writeComment writes text as a line comment wrapped at 80 columns.
+func writeComment(buf *bytes.Buffer, text string) {
+	if text == "" {
+		return
+	}
+	line := "//"
+	for _, word := range strings.Fields(text) {
+		if len(line)+1+len(word) > 80 && line != "//" {
+			buf.WriteString(line + "\n")
+			line = "//"
+		}
+		line += " " + word
+	}
+	buf.WriteString(line + "\n")
+}
+
+// camelCase turns a hyphenated tag name into an exported method name.
+func camelCase(tag string) string {
+	parts := strings.Split(tag, "-")
+	for i, part := range parts {
+		parts[i] = strings.Title(part)
+	}
+	return strings.Join(parts, "")
 }
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr string
+	}{
+		{"valid", `{"version": 1, "elements": [{"name": "p"}, {"name": "circle", "namespace": "svg"}]}`, ""},
+		{"wrong version", `{"version": 2, "elements": []}`, "unsupported version 2"},
+		{"missing name", `{"version": 1, "elements": [{"void": true}]}`, "element 0 has no name"},
+		{"unknown namespace", `{"version": 1, "elements": [{"name": "x", "namespace": "xul"}]}`, `unknown namespace "xul"`},
+		{"void svg", `{"version": 1, "elements": [{"name": "x", "namespace": "svg", "void": true}]}`, "only HTML elements can be void"},
+		{"method clash", `{"version": 1, "elements": [{"name": "font-face"}, {"name": "fontFace"}]}`, "method FontFace is already generated"},
+		{"bad json", `{"version": 1,`, "unexpected end of JSON input"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadSpec([]byte(tt.json))
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("loadSpec: unexpected error %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("loadSpec error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	elements, err := loadSpec([]byte(`{"version": 1, "elements": [
+		{"name": "img", "void": true, "categories": ["flow", "phrasing"], "attributes": ["src", "fetchpriority"], "doc": "An image."},
+		{"name": "font", "deprecated": "Use CSS instead."},
+		{"name": "circle", "namespace": "svg", "attributes": ["r"]},
+		{"name": "mi", "namespace": "math"}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := generate(config{pkg: "htmlsimple"}, elements)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"func (e *Element) Img() *VoidElement {\n\treturn e.AddVoid(VoidTag(\"img\"))",
+		"// An image.\n//\n// Element-specific attributes: src, fetchpriority.\n",
+		"// Deprecated: Use CSS instead.\nfunc (e *Element) Font() *Element",
+		"func (e *Element) SvgCircle() *Element {\n\treturn e.AddSvg(SvgTag(\"circle\"))",
+		"func (e *Element) Mi() *Element {\n\treturn e.AddMath(MathTag(\"mi\"))",
+		"\"img\": {\"flow\", \"phrasing\"},",
+		"var elementAttributes = map[string][]string{\n\t\"img\": {\"src\", \"fetchpriority\"},\n}",
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("generated source is missing %q", want)
+		}
+	}
+}
+
+func TestTagsUpToDate(t *testing.T) {
+	elements, err := loadSpec(elementsJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := generate(config{pkg: "htmlsimple"}, elements)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := check("../../tags_gen.go", src); err != nil {
+		t.Error(err)
+	}
+}
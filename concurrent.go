@@ -0,0 +1,190 @@
+package htmlsimple
+
+import (
+	"bytes"
+	"sync"
+)
+
+// parallelMinNodes is the number of nodes a subtree needs before it is
+// worth rendering on its own goroutine.
+const parallelMinNodes = 64
+
+// Fragment returns a new detached element without a tag that belongs to g.
+// Fragments let independent parts of a page be built on separate
+// goroutines: each goroutine builds its own fragment, and once they are
+// done the fragments are attached with Append. A tree must not be modified
+// from more than one goroutine at a time.
+//
+// Example:
+//
+//	parts := make([]*htmlsimple.Element, len(sections))
+//	var wg sync.WaitGroup
+//	for i, s := range sections {
+//		wg.Add(1)
+//		go func() {
+//			defer wg.Done()
+//			parts[i] = g.Fragment()
+//			parts[i].Section().AddString(s.Title)
+//		}()
+//	}
+//	wg.Wait()
+//	body.Append(parts...)
+func (g *Generator) Fragment() *Element {
+	return &Element{Tag: NormalTag(""), generator: g}
+}
+
+// Append moves children to the end of the current element and returns the
+// current element. A fragment contributes its children rather than itself.
+// An element that already has a parent is removed from it first, and one
+// built by another generator moves to this one together with its ids and
+// placeholders.
+func (e *Element) Append(children ...*Element) *Element {
+	for _, child := range children {
+		if child == nil || child == e {
+			continue
+		}
+		if e.isVoid() {
+			e.generator.report(e, SeverityError, "element appended to void element is not rendered")
+		}
+		if child.Tag.name() == "" {
+			for _, c := range child.Children {
+				if el, ok := c.(*Element); ok {
+					el.Parent = e
+					el.adopt(e.generator)
+				}
+			}
+			e.Children = append(e.Children, child.Children...)
+			child.Children = nil
+			continue
+		}
+		child.detach()
+		child.Parent = e
+		child.adopt(e.generator)
+		e.Children = append(e.Children, child)
+	}
+	return e
+}
+
+// detach removes e from the children of its parent.
+func (e *Element) detach() {
+	if e.Parent == nil {
+		return
+	}
+	siblings := e.Parent.Children
+	for i, c := range siblings {
+		if c == elementI(e) {
+			e.Parent.Children = append(siblings[:i:i], siblings[i+1:]...)
+			break
+		}
+	}
+	e.Parent = nil
+}
+
+// forget releases what g records about e and its descendants once they
+// have left g's tree: their ids become free and their placeholders can no
+// longer be filled through g.
+func (g *Generator) forget(e *Element) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	e.walkElements(func(el *Element) {
+		if id := el.Attributes["id"]; len(id) > 0 {
+			g.untrackID(el, id[0])
+		}
+		if el.placeholder != "" {
+			g.removePlaceholder(el)
+		}
+	})
+}
+
+// adopt moves e and its descendants to g, together with their ids and
+// placeholders, so that diagnostics, id checks and Fill follow them into
+// g's tree.
+func (e *Element) adopt(g *Generator) {
+	from := e.generator
+	if from == g {
+		return
+	}
+	if from != nil {
+		from.forget(e)
+	}
+	e.walkElements(func(el *Element) {
+		el.generator = g
+		if id := el.Attributes["id"]; len(id) > 0 {
+			g.trackID(el, nil, id[0])
+		}
+		if el.placeholder != "" {
+			g.mu.Lock()
+			g.addPlaceholder(el)
+			g.mu.Unlock()
+		}
+	})
+}
+
+// walkElements calls fn for e and every element below it.
+func (e *Element) walkElements(fn func(*Element)) {
+	fn(e)
+	for _, c := range e.Children {
+		if el, ok := c.(*Element); ok {
+			el.walkElements(fn)
+		}
+	}
+}
+
+// generateChildrenParallel renders the children of e into separate buffers,
+// large subtrees on their own goroutine while workers are free, and writes
+// the buffers out in order.
+func (r *renderer) generateChildrenParallel(e *Element) {
+	parts := make([]*bytes.Buffer, len(e.Children))
+	var wg sync.WaitGroup
+	for i, child := range e.Children {
+		sub := &renderer{
+			builder:      getBuffer(),
+			opts:         r.opts,
+			top:          r.top,
+			preformatted: r.preformatted,
+			workers:      r.workers,
+		}
+		parts[i] = sub.builder
+		if i+1 < len(e.Children) {
+			sub.next = e.Children[i+1]
+		}
+
+		if el, ok := child.(*Element); ok && el.countNodes(parallelMinNodes) >= parallelMinNodes {
+			select {
+			case r.workers <- struct{}{}:
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					defer func() { <-r.workers }()
+					child.generateHtml(sub)
+				}()
+				continue
+			default:
+			}
+		}
+		child.generateHtml(sub)
+	}
+	wg.Wait()
+
+	r.next = nil
+	for _, part := range parts {
+		r.builder.Write(part.Bytes())
+		putBuffer(part)
+	}
+}
+
+// countNodes counts e and its descendants, stopping once limit is reached.
+func (e *Element) countNodes(limit int) int {
+	n := 1
+	for _, c := range e.Children {
+		if n >= limit {
+			break
+		}
+		if el, ok := c.(*Element); ok {
+			n += el.countNodes(limit - n)
+		} else {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,138 @@
+package htmlsimple
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// bigPage builds a page with sections large enough to be rendered on
+// their own goroutines.
+func bigPage(g *Generator, sections int) {
+	body := g.Root.Html().Body()
+	for s := 0; s < sections; s++ {
+		section := body.Section().Attr("id", "s"+strconv.Itoa(s))
+		ul := section.Ul()
+		for i := 0; i < 100; i++ {
+			ul.Li().AddString("item " + strconv.Itoa(i)).P().AddString("text")
+		}
+	}
+}
+
+func TestParallelRenderMatchesSequential(t *testing.T) {
+	g := New(nil)
+	bigPage(g, 8)
+
+	for _, opts := range []RenderOptions{{}, {Minify: true}, {XHTML: true}, {Pretty: true}} {
+		want := g.GenerateWith(opts)
+		for _, workers := range []int{2, 4, 16} {
+			parallel := opts
+			parallel.Parallel = workers
+			if got := g.GenerateWith(parallel); got != want {
+				t.Errorf("%+v: parallel output differs from sequential", parallel)
+			}
+		}
+	}
+}
+
+func TestFragmentsBuiltConcurrently(t *testing.T) {
+	g := New(nil)
+	ul := g.Root.Ul()
+
+	parts := make([]*Element, 8)
+	var wg sync.WaitGroup
+	for i := range parts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			parts[i] = g.Fragment()
+			parts[i].Li().AddString(strconv.Itoa(i)).Attr("onclick", "x")
+		}()
+	}
+	wg.Wait()
+	ul.Append(parts...)
+
+	want := `<ul>`
+	for i := range parts {
+		want += `<li data-onclick="x">` + strconv.Itoa(i) + `</li>`
+	}
+	want += `</ul>`
+	if got := g.Generate(); got != want {
+		t.Errorf("Generate = %q, want %q", got, want)
+	}
+	if n := len(g.Diagnostics()); n != len(parts) {
+		t.Errorf("got %d diagnostics, want %d", n, len(parts))
+	}
+}
+
+func TestAppend(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(g *Generator)
+		want  string
+	}{
+		{
+			name: "fragment contributes its children",
+			build: func(g *Generator) {
+				f := g.Fragment()
+				f.Li().AddString("a")
+				f.Li().AddString("b")
+				g.Root.Ul().Append(f)
+				g.Root.Ol().Append(f)
+			},
+			want: `<ul><li>a</li><li>b</li></ul><ol></ol>`,
+		},
+		{
+			name: "element is moved",
+			build: func(g *Generator) {
+				from := g.Root.Div()
+				p := from.P().AddString("moved")
+				from.Span()
+				g.Root.Section().Append(p)
+			},
+			want: `<div><span></span></div><section><p>moved</p></section>`,
+		},
+		{
+			name: "nil and self are ignored",
+			build: func(g *Generator) {
+				div := g.Root.Div()
+				div.Append(nil, div)
+			},
+			want: `<div></div>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(nil)
+			tt.build(g)
+			if got := g.Generate(); got != tt.want {
+				t.Errorf("Generate = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGeneratorsShareDefaultPolicy(t *testing.T) {
+	var wg sync.WaitGroup
+	outputs := make([]string, 16)
+	for i := range outputs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			g := New(nil)
+			g.Root.A().Attr("href", "/"+strconv.Itoa(i)).AddString("link")
+			outputs[i] = g.GenerateWith(RenderOptions{Parallel: 4})
+		}()
+	}
+	wg.Wait()
+
+	for i, got := range outputs {
+		if want := `<a href="/` + strconv.Itoa(i) + `">link</a>`; got != want {
+			t.Errorf("output %d = %q, want %q", i, got, want)
+		}
+	}
+	if New(nil).policy != DefaultPolicy() {
+		t.Error("New(nil) does not share the default policy")
+	}
+}
@@ -0,0 +1,162 @@
+package htmlsimple
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// CustomElement describes an autonomous custom element such as <my-widget>,
+// together with the attributes and slots it accepts. It is used as the Tag
+// of elements created with AddCustom.
+type CustomElement struct {
+	tagName    string
+	attributes map[string]attributeConfig
+	slots      map[string]bool
+}
+
+func (c *CustomElement) name() string { return c.tagName }
+
+// ShadowRootMode is the mode of a declarative shadow root.
+type ShadowRootMode string
+
+const (
+	ShadowRootOpen   ShadowRootMode = "open"
+	ShadowRootClosed ShadowRootMode = "closed"
+)
+
+// Names the HTML spec reserves, even though they look like custom elements.
+var reservedCustomElementNames = map[string]bool{
+	"annotation-xml":   true,
+	"color-profile":    true,
+	"font-face":        true,
+	"font-face-src":    true,
+	"font-face-uri":    true,
+	"font-face-format": true,
+	"font-face-name":   true,
+	"missing-glyph":    true,
+}
+
+// NewCustomElement defines a custom element. The name must be a valid
+// custom element name: it starts with a lowercase ASCII letter, contains a
+// hyphen and has no uppercase letters.
+//
+// Attributes lists the attributes the element accepts in addition to the
+// generator's allowed attributes. An attribute the generator already allows,
+// such as href, keeps its sanitizing, and event handler attributes (on*)
+// cannot be declared. Slots lists the slot names children may be assigned
+// to; when it is empty any slot name is accepted.
+//
+// Example:
+//
+//	widget, err := NewCustomElement("my-widget", []Attribute{{Name: "variant"}}, []string{"title"})
+//	w := element.AddCustom(widget).Attr("variant", "compact")
+//	w.H2().Attr("slot", "title").AddString("Hello")
+func NewCustomElement(name string, attributes []Attribute, slots []string) (*CustomElement, error) {
+	if err := validateCustomElementName(name); err != nil {
+		return nil, err
+	}
+
+	c := &CustomElement{
+		tagName:    name,
+		attributes: make(map[string]attributeConfig),
+	}
+	for _, attribute := range attributes {
+		if !validAttributeName(attribute.Name) {
+			return nil, fmt.Errorf("htmlsimple: invalid attribute name %q for custom element %q", attribute.Name, name)
+		}
+		if strings.HasPrefix(strings.ToLower(attribute.Name), "on") {
+			return nil, fmt.Errorf("htmlsimple: custom element %q cannot declare event handler attribute %q", name, attribute.Name)
+		}
+		c.attributes[attribute.Name] = attributeConfig{allowed: true, sanitizeFunc: html.EscapeString}
+	}
+	if len(slots) > 0 {
+		c.slots = make(map[string]bool)
+		for _, slot := range slots {
+			c.slots[slot] = true
+		}
+	}
+	return c, nil
+}
+
+// MustCustomElement is like NewCustomElement but panics if the name is
+// invalid. It simplifies defining custom elements in package variables.
+func MustCustomElement(name string, attributes []Attribute, slots []string) *CustomElement {
+	c, err := NewCustomElement(name, attributes, slots)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func validateCustomElementName(name string) error {
+	if name == "" || name[0] < 'a' || name[0] > 'z' {
+		return fmt.Errorf("htmlsimple: custom element name %q must start with a lowercase ASCII letter", name)
+	}
+	if !strings.Contains(name, "-") {
+		return fmt.Errorf("htmlsimple: custom element name %q must contain a hyphen", name)
+	}
+	if reservedCustomElementNames[name] {
+		return fmt.Errorf("htmlsimple: custom element name %q is reserved", name)
+	}
+	for _, c := range name {
+		if !isPCENChar(c) {
+			return fmt.Errorf("htmlsimple: custom element name %q contains invalid character %q", name, c)
+		}
+	}
+	return nil
+}
+
+// isPCENChar reports whether c may appear in a custom element name.
+func isPCENChar(c rune) bool {
+	switch {
+	case c == '-', c == '.', c == '_', c >= '0' && c <= '9', c >= 'a' && c <= 'z':
+		return true
+	case c == 0xB7,
+		c >= 0xC0 && c <= 0xD6,
+		c >= 0xD8 && c <= 0xF6,
+		c >= 0xF8 && c <= 0x37D,
+		c >= 0x37F && c <= 0x1FFF,
+		c >= 0x200C && c <= 0x200D,
+		c >= 0x203F && c <= 0x2040,
+		c >= 0x2070 && c <= 0x218F,
+		c >= 0x2C00 && c <= 0x2FEF,
+		c >= 0x3001 && c <= 0xD7FF,
+		c >= 0xF900 && c <= 0xFDCF,
+		c >= 0xFDF0 && c <= 0xFFFD,
+		c >= 0x10000 && c <= 0xEFFFF:
+		return true
+	}
+	return false
+}
+
+// AddCustom creates and adds a child custom element to the current element.
+func (e *Element) AddCustom(c *CustomElement) *Element {
+	return e.addChild(c)
+}
+
+// ShadowRoot adds a declarative shadow root to the current element and
+// returns it. Children added to the returned <template> make up the shadow
+// tree; the browser attaches it to the current element while parsing.
+//
+// Example:
+//
+//	shadow := element.AddCustom(widget).ShadowRoot(ShadowRootOpen)
+//	shadow.Slot().Attr("name", "title")
+func (e *Element) ShadowRoot(mode ShadowRootMode) *Element {
+	return e.Template().Attr("shadowrootmode", string(mode))
+}
+
+// slotAllowed reports whether e may be assigned to the named slot of its
+// parent. Only custom elements that declare their slots restrict this.
+func (e *Element) slotAllowed(slot string) bool {
+	parent := e.outerParent()
+	if parent == nil {
+		return true
+	}
+	c, ok := parent.Tag.(*CustomElement)
+	if !ok || c.slots == nil {
+		return true
+	}
+	return c.slots[slot]
+}
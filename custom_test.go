@@ -0,0 +1,145 @@
+package htmlsimple
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewCustomElementNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr string
+	}{
+		{"my-widget", ""},
+		{"x-a.b_c", ""},
+		{"math-α", ""},
+		{"widget", "must contain a hyphen"},
+		{"My-widget", "must start with a lowercase ASCII letter"},
+		{"1-widget", "must start with a lowercase ASCII letter"},
+		{"", "must start with a lowercase ASCII letter"},
+		{"my-Widget", "invalid character 'W'"},
+		{"my widget-x", "invalid character ' '"},
+		{"font-face", "is reserved"},
+		{"annotation-xml", "is reserved"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCustomElement(tt.name, nil, nil)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("unexpected error %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	attributes := []struct {
+		name    string
+		wantErr string
+	}{
+		{"variant", ""},
+		{"bad name", "invalid attribute name"},
+		{"onclick", "event handler"},
+		{"ONLOAD", "event handler"},
+	}
+	for _, tt := range attributes {
+		t.Run("attribute "+tt.name, func(t *testing.T) {
+			_, err := NewCustomElement("my-widget", []Attribute{{Name: tt.name}}, nil)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("unexpected error %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCustomElements(t *testing.T) {
+	widget := MustCustomElement("my-widget", []Attribute{{Name: "variant"}}, []string{"title"})
+	open := MustCustomElement("open-slots", nil, nil)
+	link := MustCustomElement("x-link", []Attribute{{Name: "href"}, {Name: "src"}}, nil)
+
+	tests := []struct {
+		name  string
+		build func(root *Element)
+		want  string
+		warns bool
+	}{
+		{
+			name: "declared attribute",
+			build: func(root *Element) {
+				root.AddCustom(widget).Attr("variant", "compact")
+			},
+			want: `<my-widget variant="compact"></my-widget>`,
+		},
+		{
+			name: "global attributes still allowed",
+			build: func(root *Element) {
+				root.AddCustom(widget).Attr("class", "card")
+			},
+			want: `<my-widget class="card"></my-widget>`,
+		},
+		{
+			name: "undeclared attribute",
+			build: func(root *Element) {
+				root.AddCustom(widget).Attr("tone", "2")
+			},
+			want:  `<my-widget data-tone="2"></my-widget>`,
+			warns: true,
+		},
+		{
+			name: "declared url attribute stays sanitized",
+			build: func(root *Element) {
+				root.AddCustom(link).Attr("href", "javascript:alert(1)").Attr("src", "/logo.png")
+			},
+			want:  `<x-link href="#" src="/logo.png"></x-link>`,
+			warns: true,
+		},
+		{
+			name: "declared slot",
+			build: func(root *Element) {
+				root.AddCustom(widget).H2().Attr("slot", "title").AddString("Hi")
+			},
+			want: `<my-widget><h2 slot="title">Hi</h2></my-widget>`,
+		},
+		{
+			name: "undeclared slot",
+			build: func(root *Element) {
+				root.AddCustom(widget).Span().Attr("slot", "footer")
+			},
+			want:  `<my-widget><span data-slot="footer"></span></my-widget>`,
+			warns: true,
+		},
+		{
+			name: "any slot when none are declared",
+			build: func(root *Element) {
+				root.AddCustom(open).Span().Attr("slot", "footer")
+			},
+			want: `<open-slots><span slot="footer"></span></open-slots>`,
+		},
+		{
+			name: "declarative shadow root",
+			build: func(root *Element) {
+				shadow := root.AddCustom(widget).ShadowRoot(ShadowRootOpen)
+				shadow.Slot().Attr("name", "title")
+			},
+			want: `<my-widget><template shadowrootmode="open"><slot name="title"></slot></template></my-widget>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(nil)
+			tt.build(g.Root)
+			if got := g.Generate(); got != tt.want {
+				t.Errorf("Generate = %q, want %q", got, tt.want)
+			}
+			if warns := len(g.Diagnostics()) > 0; warns != tt.warns {
+				t.Errorf("diagnostics = %v, want warning %v", g.Diagnostics(), tt.warns)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package htmlsimple
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Severity tells how serious a Diagnostic is.
+type Severity int
+
+const (
+	// SeverityWarning marks input that was coerced into something safe,
+	// such as an unknown attribute rendered as data-*.
+	SeverityWarning Severity = iota
+	// SeverityError marks input that was dropped or will not render.
+	SeverityError
+)
+
+func (s Severity) String() string {
+	if s == SeverityError {
+		return "error"
+	}
+	return "warning"
+}
+
+// Diagnostic records a single place where the fluent API silently fixed or
+// dropped input.
+type Diagnostic struct {
+	Severity Severity
+	// Path identifies the element, e.g. "html > body > ul > li[2]".
+	Path string
+	// Source is the file and line of the call that caused it.
+	Source string
+	// Reason describes what happened.
+	Reason string
+}
+
+func (d Diagnostic) Error() string {
+	return fmt.Sprintf("%s: %s: %s (%s)", d.Source, d.Path, d.Reason, d.Severity)
+}
+
+// Diagnostics returns everything recorded while building the tree, in the
+// order it happened.
+func (g *Generator) Diagnostics() []Diagnostic {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]Diagnostic(nil), g.diagnostics...)
+}
+
+// Err returns the diagnostics with SeverityError joined into one error, or
+// nil if there are none.
+func (g *Generator) Err() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	var errs []error
+	for _, d := range g.diagnostics {
+		if d.Severity == SeverityError {
+			errs = append(errs, d)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// report records a diagnostic for e. It is safe to call from goroutines
+// building separate fragments.
+func (g *Generator) report(e *Element, severity Severity, format string, args ...any) {
+	d := Diagnostic{
+		Severity: severity,
+		Path:     e.Path(),
+		Source:   callerOutsidePackage(),
+		Reason:   fmt.Sprintf(format, args...),
+	}
+	g.mu.Lock()
+	g.diagnostics = append(g.diagnostics, d)
+	g.mu.Unlock()
+}
+
+// Path returns a readable location of e in its tree, such as
+// "html > body > div#main > a". Siblings with the same tag and no id are
+// told apart by a 1-based index.
+func (e *Element) Path() string {
+	var segments []string
+	for el := e; el != nil && el.Tag.name() != ""; el = el.outerParent() {
+		segments = append(segments, el.pathSegment())
+	}
+	if len(segments) == 0 {
+		return "(root)"
+	}
+
+	for i, j := 0, len(segments)-1; i < j; i, j = i+1, j-1 {
+		segments[i], segments[j] = segments[j], segments[i]
+	}
+	return strings.Join(segments, " > ")
+}
+
+func (e *Element) pathSegment() string {
+	name := e.Tag.name()
+	if id, ok := e.Attributes["id"]; ok && len(id) > 0 {
+		return name + "#" + id[0]
+	}
+	if e.Parent == nil {
+		return name
+	}
+
+	index, count := 0, 0
+	for _, child := range e.Parent.Children {
+		c, ok := child.(*Element)
+		if !ok || c.Tag.name() != name {
+			continue
+		}
+		count++
+		if c == e {
+			index = count
+		}
+	}
+	if count > 1 && index > 0 {
+		return name + "[" + strconv.Itoa(index) + "]"
+	}
+	return name
+}
+
+var packagePath = reflect.TypeOf(Generator{}).PkgPath()
+
+// callerOutsidePackage returns file:line of the first caller that is not
+// part of this package.
+func callerOutsidePackage() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, packagePath+".") {
+			return frame.File + ":" + strconv.Itoa(frame.Line)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
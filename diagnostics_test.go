@@ -0,0 +1,86 @@
+package htmlsimple_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/PatrickVerhagen/htmlsimple"
+)
+
+func TestDiagnostics(t *testing.T) {
+	tests := []struct {
+		name     string
+		build    func(root *htmlsimple.Element)
+		severity htmlsimple.Severity
+		path     string
+		reason   string
+	}{
+		{
+			name: "attribute rendered as data",
+			build: func(root *htmlsimple.Element) {
+				root.Html().Body().Div().Attr("onclick", "x")
+			},
+			severity: htmlsimple.SeverityWarning,
+			path:     "html > body > div",
+			reason:   `attribute "onclick" is not allowed, rendered as data-onclick`,
+		},
+		{
+			name: "unsafe url",
+			build: func(root *htmlsimple.Element) {
+				ul := root.Ul()
+				ul.Li()
+				ul.Li().A().Attr("href", "javascript:alert(1)")
+			},
+			severity: htmlsimple.SeverityWarning,
+			path:     "ul > li[2] > a",
+			reason:   `unsafe URL "javascript:alert(1)" in href replaced with #`,
+		},
+		{
+			name: "path uses id",
+			build: func(root *htmlsimple.Element) {
+				root.Div().Attr("id", "main").Span().Attr("onclick", "x")
+			},
+			severity: htmlsimple.SeverityWarning,
+			path:     "div#main > span",
+			reason:   `attribute "onclick" is not allowed, rendered as data-onclick`,
+		},
+		{
+			name: "invalid name",
+			build: func(root *htmlsimple.Element) {
+				root.Section().Add(htmlsimple.NormalTag("a b"))
+			},
+			severity: htmlsimple.SeverityError,
+			path:     "section",
+			reason:   `invalid element name "a b" dropped`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := htmlsimple.New(nil)
+			tt.build(g.Root)
+
+			diagnostics := g.Diagnostics()
+			if len(diagnostics) != 1 {
+				t.Fatalf("Diagnostics = %v, want one", diagnostics)
+			}
+			d := diagnostics[0]
+			if d.Severity != tt.severity || d.Path != tt.path || d.Reason != tt.reason {
+				t.Errorf("Diagnostic = %+v, want %v at %q: %q", d, tt.severity, tt.path, tt.reason)
+			}
+			if !strings.Contains(d.Source, "diagnostics_test.go:") {
+				t.Errorf("Source = %q, want the calling line in diagnostics_test.go", d.Source)
+			}
+
+			err := g.Err()
+			if gotErr := err != nil; gotErr != (tt.severity == htmlsimple.SeverityError) {
+				t.Errorf("Err = %v", err)
+			}
+			var de htmlsimple.Diagnostic
+			if err != nil && (!errors.As(err, &de) || de != d) {
+				t.Errorf("Err does not wrap %v", d)
+			}
+		})
+	}
+}
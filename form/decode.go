@@ -0,0 +1,243 @@
+package form
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/PatrickVerhagen/htmlsimple"
+)
+
+// Decode binds submitted values into dst, a pointer to a struct described
+// by the same tags Render uses, and checks the constraints Render emits as
+// HTML attributes: required, minlength, maxlength, pattern, min, max and
+// step, plus the options of a select.
+//
+// Values that fail validation are still stored when they can be parsed, so
+// the form can be rendered again with what the user typed. The returned
+// Errors is empty when everything is valid; the error is only non-nil when
+// dst cannot be decoded into at all.
+func Decode(values url.Values, dst any) (Errors, error) {
+	value := reflect.ValueOf(dst)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return nil, fmt.Errorf("form: Decode needs a non-nil pointer, got %T", dst)
+	}
+	value, err := structValue(dst)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := parseFields(value.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	errs := make(Errors)
+	for _, f := range fields {
+		raw := values.Get(f.name)
+		if f.inputType == "checkbox" {
+			_, checked := values[f.name]
+			raw = ""
+			if checked {
+				raw = "true"
+			}
+		}
+
+		if message := f.validate(raw, value.Field(f.index)); message != "" {
+			errs[f.name] = message
+		}
+	}
+	return errs, nil
+}
+
+// Handle decodes the form submitted with r into dst. When the submission
+// is valid it returns true. Otherwise it renders the form under parent with
+// the submitted values and error messages and returns false, so a handler
+// only has to deal with the valid case.
+//
+// POST, PUT and PATCH submissions are read from the request body only;
+// other methods, such as a GET form, from the URL query.
+func Handle(r *http.Request, parent *htmlsimple.Element, dst any, opts Options) (bool, error) {
+	if err := r.ParseForm(); err != nil {
+		return false, err
+	}
+	values := r.Form
+	switch r.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		values = r.PostForm
+	}
+	errs, err := Decode(values, dst)
+	if err != nil {
+		return false, err
+	}
+	if len(errs) == 0 {
+		return true, nil
+	}
+
+	opts.Errors = errs
+	if _, err := Render(parent, dst, opts); err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
+// validate stores raw in target and returns an error message if it breaks
+// one of the field's constraints.
+func (f field) validate(raw string, target reflect.Value) string {
+	if raw == "" {
+		target.Set(reflect.Zero(target.Type()))
+		if f.required {
+			return fmt.Sprintf("%s is required.", f.label)
+		}
+		return ""
+	}
+
+	if err := setValue(target, raw); err != nil {
+		return fmt.Sprintf("%s is not a valid %s.", f.label, f.inputType)
+	}
+
+	length := utf8.RuneCountInString(raw)
+	if f.minLength > 0 && length < f.minLength {
+		return fmt.Sprintf("%s must be at least %d characters.", f.label, f.minLength)
+	}
+	if f.maxLength > 0 && length > f.maxLength {
+		return fmt.Sprintf("%s must be at most %d characters.", f.label, f.maxLength)
+	}
+	if f.pattern != "" {
+		// Like the browser, the pattern has to match the whole value.
+		re, err := regexp.Compile("^(?:" + f.pattern + ")$")
+		if err != nil || !re.MatchString(raw) {
+			return fmt.Sprintf("%s has an invalid format.", f.label)
+		}
+	}
+	if f.inputType == "select" && len(f.options) > 0 && !contains(f.options, raw) {
+		return fmt.Sprintf("%s has an invalid choice.", f.label)
+	}
+	return f.validateRange(raw)
+}
+
+// validateRange checks min, max and step against raw, which has already
+// been parsed successfully.
+func (f field) validateRange(raw string) string {
+	if f.inputType == "date" {
+		if f.min != "" && raw < f.min {
+			return fmt.Sprintf("%s must be on or after %s.", f.label, f.min)
+		}
+		if f.max != "" && raw > f.max {
+			return fmt.Sprintf("%s must be on or before %s.", f.label, f.max)
+		}
+		return ""
+	}
+
+	n, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return ""
+	}
+	base := "0"
+	if f.min != "" {
+		min, err := strconv.ParseFloat(f.min, 64)
+		if err == nil && n < min {
+			return fmt.Sprintf("%s must be at least %s.", f.label, f.min)
+		}
+		base = f.min
+	}
+	if f.max != "" {
+		max, err := strconv.ParseFloat(f.max, 64)
+		if err == nil && n > max {
+			return fmt.Sprintf("%s must be at most %s.", f.label, f.max)
+		}
+	}
+	if f.step != "" && f.step != "any" && !onStep(raw, base, f.step) {
+		return fmt.Sprintf("%s must be in steps of %s.", f.label, f.step)
+	}
+	return ""
+}
+
+// onStep reports whether raw is a whole number of steps away from base.
+// The values are compared as exact decimals, since in floating point 0.3 is
+// not a multiple of 0.1. Values that cannot be parsed are not rejected.
+func onStep(raw, base, step string) bool {
+	n, ok1 := new(big.Rat).SetString(raw)
+	b, ok2 := new(big.Rat).SetString(base)
+	s, ok3 := new(big.Rat).SetString(step)
+	if !ok1 || !ok2 || !ok3 || s.Sign() <= 0 {
+		return true
+	}
+	steps := n.Sub(n, b)
+	return steps.Quo(steps, s).IsInt()
+}
+
+// validNumber matches a valid floating-point number as defined by HTML.
+// Unlike strconv.ParseFloat it rejects NaN, infinities and hex floats.
+var validNumber = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?([eE][-+]?[0-9]+)?$`)
+
+// setValue parses raw into target according to its type. Floats have to be
+// written the way a browser submits them.
+func setValue(target reflect.Value, raw string) error {
+	if target.Kind() == reflect.Pointer {
+		v := reflect.New(target.Type().Elem())
+		if err := setValue(v.Elem(), raw); err != nil {
+			return err
+		}
+		target.Set(v)
+		return nil
+	}
+
+	if target.Type() == timeType {
+		t, err := time.Parse(dateLayout, raw)
+		if err != nil {
+			return err
+		}
+		target.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch target.Kind() {
+	case reflect.String:
+		target.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		target.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		if !validNumber.MatchString(raw) {
+			return fmt.Errorf("invalid number %q", raw)
+		}
+		n, err := strconv.ParseFloat(raw, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported type %s", target.Type())
+	}
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+package form_test
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PatrickVerhagen/htmlsimple"
+	"github.com/PatrickVerhagen/htmlsimple/form"
+)
+
+type order struct {
+	Email    string    `form:"email" label:"Email" validate:"required,minlength=3,maxlength=20"`
+	Code     string    `form:"code" label:"Code" pattern:"[A-Z]{3}"`
+	Plan     string    `form:"plan" label:"Plan" input:"select" options:"free,pro"`
+	Quantity int       `form:"quantity" label:"Quantity" validate:"min=1,max=10"`
+	Pack     int       `form:"pack" label:"Pack" validate:"min=1,step=5"`
+	Weight   float64   `form:"weight" label:"Weight" validate:"step=0.1"`
+	Price    float64   `form:"price" label:"Price" validate:"min=0,step=0.01"`
+	Ratio    float64   `form:"ratio" label:"Ratio" validate:"step=any"`
+	Gift     bool      `form:"gift" label:"Gift"`
+	Deliver  time.Time `form:"deliver" label:"Deliver" validate:"min=2024-01-01,max=2024-12-31"`
+}
+
+func TestDecodeValidation(t *testing.T) {
+	valid := url.Values{"email": {"a@b.c"}}
+
+	tests := []struct {
+		name  string
+		set   url.Values
+		field string
+		want  string
+	}{
+		{"valid", nil, "", ""},
+		{"required", url.Values{"email": {""}}, "email", "Email is required."},
+		{"minlength", url.Values{"email": {"ab"}}, "email", "Email must be at least 3 characters."},
+		{"maxlength counts runes", url.Values{"email": {strings.Repeat("é", 20)}}, "", ""},
+		{"maxlength", url.Values{"email": {strings.Repeat("a", 21)}}, "email", "Email must be at most 20 characters."},
+		{"pattern matches whole value", url.Values{"code": {"ABCD"}}, "code", "Code has an invalid format."},
+		{"pattern", url.Values{"code": {"ABC"}}, "", ""},
+		{"select choice", url.Values{"plan": {"gold"}}, "plan", "Plan has an invalid choice."},
+		{"not a number", url.Values{"quantity": {"two"}}, "quantity", "Quantity is not a valid number."},
+		{"min", url.Values{"quantity": {"0"}}, "quantity", "Quantity must be at least 1."},
+		{"max", url.Values{"quantity": {"11"}}, "quantity", "Quantity must be at most 10."},
+		{"step from min", url.Values{"pack": {"6"}}, "", ""},
+		{"off step from min", url.Values{"pack": {"5"}}, "pack", "Pack must be in steps of 5."},
+		{"decimal step", url.Values{"weight": {"0.3"}}, "", ""},
+		{"decimal step negative", url.Values{"weight": {"-0.7"}}, "", ""},
+		{"off decimal step", url.Values{"weight": {"0.35"}}, "weight", "Weight must be in steps of 0.1."},
+		{"cent step", url.Values{"price": {"1.15"}}, "", ""},
+		{"cent step large", url.Values{"price": {"123456.79"}}, "", ""},
+		{"off cent step", url.Values{"price": {"1.155"}}, "price", "Price must be in steps of 0.01."},
+		{"nan", url.Values{"price": {"NaN"}}, "price", "Price is not a valid number."},
+		{"infinity", url.Values{"ratio": {"Inf"}}, "ratio", "Ratio is not a valid number."},
+		{"negative infinity", url.Values{"ratio": {"-infinity"}}, "ratio", "Ratio is not a valid number."},
+		{"hex float", url.Values{"price": {"0x1p-2"}}, "price", "Price is not a valid number."},
+		{"leading plus", url.Values{"ratio": {"+1"}}, "ratio", "Ratio is not a valid number."},
+		{"overflow", url.Values{"ratio": {"1e400"}}, "ratio", "Ratio is not a valid number."},
+		{"exponent", url.Values{"ratio": {"-1.5e-3"}}, "", ""},
+		{"any step", url.Values{"ratio": {"0.333333"}}, "", ""},
+		{"date min", url.Values{"deliver": {"2023-12-31"}}, "deliver", "Deliver must be on or after 2024-01-01."},
+		{"date max", url.Values{"deliver": {"2025-01-01"}}, "deliver", "Deliver must be on or before 2024-12-31."},
+		{"bad date", url.Values{"deliver": {"01/02/2024"}}, "deliver", "Deliver is not a valid date."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := url.Values{}
+			for k, v := range valid {
+				values[k] = v
+			}
+			for k, v := range tt.set {
+				values[k] = v
+			}
+
+			var dst order
+			errs, err := form.Decode(values, &dst)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.field == "" {
+				if len(errs) > 0 {
+					t.Errorf("Decode errors = %v, want none", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || errs[tt.field] != tt.want {
+				t.Errorf("Decode errors = %v, want %s: %q", errs, tt.field, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeStoresValues(t *testing.T) {
+	var dst order
+	errs, err := form.Decode(url.Values{
+		"email":    {"a@b.c"},
+		"quantity": {"42"},
+		"price":    {"9.99"},
+		"gift":     {"on"},
+		"deliver":  {"2024-06-01"},
+	}, &dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 1 {
+		t.Errorf("Decode errors = %v, want only quantity", errs)
+	}
+	// Invalid values are kept so the form can show them again.
+	if dst.Email != "a@b.c" || dst.Quantity != 42 || dst.Price != 9.99 || !dst.Gift ||
+		!dst.Deliver.Equal(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Decode stored %+v", dst)
+	}
+}
+
+func TestDecodeNeedsPointer(t *testing.T) {
+	for _, dst := range []any{order{}, (*order)(nil), new(int)} {
+		if _, err := form.Decode(url.Values{}, dst); err == nil {
+			t.Errorf("Decode(%T) did not fail", dst)
+		}
+	}
+}
+
+func TestHandle(t *testing.T) {
+	type search struct {
+		Query string `form:"q" label:"Query" validate:"required"`
+	}
+
+	tests := []struct {
+		name      string
+		method    string
+		target    string
+		body      string
+		wantValid bool
+		wantQuery string
+	}{
+		{"get", "GET", "/search?q=shoes", "", true, "shoes"},
+		{"get missing", "GET", "/search", "", false, ""},
+		{"post", "POST", "/search", "q=boots", true, "boots"},
+		{"post ignores query", "POST", "/search?q=shoes", "", false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.body != "" {
+				r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			}
+
+			g := htmlsimple.New(nil)
+			var dst search
+			valid, err := form.Handle(r, g.Root, &dst, form.Options{Method: strings.ToLower(tt.method)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if valid != tt.wantValid || dst.Query != tt.wantQuery {
+				t.Errorf("Handle = %v with %q, want %v with %q", valid, dst.Query, tt.wantValid, tt.wantQuery)
+			}
+
+			out := g.Generate()
+			if rendered := out != ""; rendered == valid {
+				t.Errorf("form rendered = %v for valid = %v: %s", rendered, valid, out)
+			}
+			if !valid && !strings.Contains(out, "Query is required.") {
+				t.Errorf("rendered form has no error message: %s", out)
+			}
+		})
+	}
+}
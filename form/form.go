@@ -0,0 +1,362 @@
+// Package form renders HTML forms from Go structs on top of htmlsimple.
+//
+// Each exported field becomes a labelled control. Struct tags describe the
+// control and its constraints:
+//
+//	type Signup struct {
+//		Email    string `form:"email" label:"Email address" input:"email" validate:"required,maxlength=100"`
+//		Age      int    `form:"age" validate:"min=18,max=130"`
+//		Username string `form:"username" validate:"required" pattern:"[a-z0-9]+"`
+//		Plan     string `form:"plan" input:"select" options:"free,pro"`
+//		Terms    bool   `form:"terms" label:"I accept the terms" validate:"required"`
+//		Internal string `form:"-"`
+//	}
+//
+// The form tag sets the field name ("-" skips the field), label the label
+// text, input the control type (text, email, password, number, checkbox,
+// date, textarea, select, hidden, ...), options the choices of a select,
+// placeholder the placeholder and pattern a regular expression. The
+// validate tag holds comma-separated constraints: required, min, max,
+// minlength, maxlength and step. They are emitted as HTML attributes so
+// the browser validates them too.
+package form
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PatrickVerhagen/htmlsimple"
+)
+
+// Errors maps field names to the error message shown next to the field.
+type Errors map[string]string
+
+// Options configures how a form is rendered.
+type Options struct {
+	// Action is the URL the form submits to.
+	Action string
+	// Method is the HTTP method, "post" by default.
+	Method string
+	// Submit is the label of the submit button, "Submit" by default.
+	Submit string
+	// Errors are rendered next to the fields they belong to.
+	Errors Errors
+	// CSRF, when set, is called once per form and its result is rendered as
+	// a hidden input, e.g. the field name and token of a CSRF middleware.
+	CSRF func() (name, token string)
+}
+
+// field is the parsed description of a single struct field.
+type field struct {
+	index       int
+	name        string
+	label       string
+	inputType   string
+	placeholder string
+	pattern     string
+	options     []string
+	required    bool
+	min         string
+	max         string
+	step        string
+	minLength   int
+	maxLength   int
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// dateLayout is the value format of <input type="date">.
+const dateLayout = "2006-01-02"
+
+// Render adds a <form> for v, a struct or pointer to struct, to parent and
+// returns it. Field values are filled in from v.
+func Render(parent *htmlsimple.Element, v any, opts Options) (*htmlsimple.Element, error) {
+	value, err := structValue(v)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := parseFields(value.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	method := opts.Method
+	if method == "" {
+		method = "post"
+	}
+	form := parent.Form().Attr("method", method)
+	if opts.Action != "" {
+		form.Attr("action", opts.Action)
+	}
+
+	if opts.CSRF != nil {
+		name, token := opts.CSRF()
+		form.Input().WithAttrs(
+			htmlsimple.KV("type", "hidden"),
+			htmlsimple.KV("name", name),
+			htmlsimple.KV("value", token),
+		)
+	}
+
+	for _, f := range fields {
+		renderField(form, f, value.Field(f.index), opts.Errors[f.name])
+	}
+
+	submit := opts.Submit
+	if submit == "" {
+		submit = "Submit"
+	}
+	form.Button().Attr("type", "submit").AddString(submit)
+	return form, nil
+}
+
+func renderField(form *htmlsimple.Element, f field, value reflect.Value, message string) {
+	current := formatValue(value)
+
+	if f.inputType == "hidden" {
+		form.Input().WithAttrs(
+			htmlsimple.KV("type", "hidden"),
+			htmlsimple.KV("name", f.name),
+			htmlsimple.KV("value", current),
+		)
+		return
+	}
+
+	wrapper := form.Div().Attr("class", "field")
+	if message != "" {
+		wrapper.Attr("class", "has-error")
+	}
+	label := wrapper.Label().AddString(f.label)
+
+	// The message is built first so the control can refer to its id, and
+	// moved after the control once that is added.
+	var errorText *htmlsimple.Element
+	var invalid []htmlsimple.KeyValue
+	if message != "" {
+		errorText = wrapper.P().Attr("class", "error").AddString(message)
+		invalid = []htmlsimple.KeyValue{
+			htmlsimple.KV("aria-invalid", "true"),
+			htmlsimple.KV("aria-describedby", errorText.ID()),
+		}
+	}
+
+	var control htmlsimple.Node
+	switch f.inputType {
+	case "textarea":
+		control = wrapper.Textarea().
+			Attr("name", f.name).
+			WithAttrs(f.constraints()...).
+			WithAttrs(invalid...).
+			AddString(current)
+	case "select":
+		sel := wrapper.Select().
+			Attr("name", f.name).
+			WithAttrs(f.constraints()...).
+			WithAttrs(invalid...)
+		if !f.required {
+			sel.Option().Attr("value", "")
+		}
+		for _, option := range f.options {
+			o := sel.Option().Attr("value", option).AddString(option)
+			if option == current {
+				o.Attr("selected", "")
+			}
+		}
+		control = sel
+	default:
+		input := wrapper.Input().WithAttrs(
+			htmlsimple.KV("type", f.inputType),
+			htmlsimple.KV("name", f.name),
+		)
+		if f.inputType == "checkbox" {
+			input.Attr("value", "true")
+			if current == "true" {
+				input.Attr("checked", "")
+			}
+		} else if current != "" {
+			input.Attr("value", current)
+		}
+		input.WithAttrs(f.constraints()...).WithAttrs(invalid...)
+		control = input
+	}
+	// Generated ids stay unique when a form is rendered twice on a page or
+	// next to elements with ids of their own.
+	label.For(control)
+
+	if errorText != nil {
+		wrapper.Append(errorText)
+	}
+}
+
+// constraints returns the validation and hint attributes of f.
+func (f field) constraints() []htmlsimple.KeyValue {
+	var attrs []htmlsimple.KeyValue
+	if f.required {
+		attrs = append(attrs, htmlsimple.KV("required", ""))
+	}
+	if f.min != "" {
+		attrs = append(attrs, htmlsimple.KV("min", f.min))
+	}
+	if f.max != "" {
+		attrs = append(attrs, htmlsimple.KV("max", f.max))
+	}
+	if f.step != "" {
+		attrs = append(attrs, htmlsimple.KV("step", f.step))
+	}
+	if f.minLength > 0 {
+		attrs = append(attrs, htmlsimple.KV("minlength", strconv.Itoa(f.minLength)))
+	}
+	if f.maxLength > 0 {
+		attrs = append(attrs, htmlsimple.KV("maxlength", strconv.Itoa(f.maxLength)))
+	}
+	if f.pattern != "" {
+		attrs = append(attrs, htmlsimple.KV("pattern", f.pattern))
+	}
+	if f.placeholder != "" {
+		attrs = append(attrs, htmlsimple.KV("placeholder", f.placeholder))
+	}
+	return attrs
+}
+
+// structValue returns the struct v refers to.
+func structValue(v any) (reflect.Value, error) {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return reflect.Value{}, fmt.Errorf("form: nil %T", v)
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("form: %T is not a struct", v)
+	}
+	return value, nil
+}
+
+// parseFields reads the struct tags of t.
+func parseFields(t reflect.Type) ([]field, error) {
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name := sf.Tag.Get("form")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+
+		f := field{
+			index:       i,
+			name:        name,
+			label:       sf.Tag.Get("label"),
+			inputType:   sf.Tag.Get("input"),
+			placeholder: sf.Tag.Get("placeholder"),
+			pattern:     sf.Tag.Get("pattern"),
+		}
+		if f.label == "" {
+			f.label = sf.Name
+		}
+		if options := sf.Tag.Get("options"); options != "" {
+			f.options = strings.Split(options, ",")
+		}
+		if f.inputType == "" {
+			inputType, err := defaultInputType(sf.Type)
+			if err != nil {
+				return nil, fmt.Errorf("form: field %s: %w", sf.Name, err)
+			}
+			f.inputType = inputType
+		}
+		if err := f.parseValidate(sf.Tag.Get("validate")); err != nil {
+			return nil, fmt.Errorf("form: field %s: %w", sf.Name, err)
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// parseValidate reads a validate tag such as "required,min=1,maxlength=20".
+func (f *field) parseValidate(tag string) error {
+	for _, rule := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		var err error
+		switch key {
+		case "":
+		case "required":
+			f.required = true
+		case "min":
+			f.min = value
+		case "max":
+			f.max = value
+		case "step":
+			f.step = value
+		case "minlength":
+			f.minLength, err = strconv.Atoi(value)
+		case "maxlength":
+			f.maxLength, err = strconv.Atoi(value)
+		default:
+			return fmt.Errorf("unknown validation rule %q", key)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// defaultInputType picks an input type for a Go type.
+func defaultInputType(t reflect.Type) (string, error) {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return "date", nil
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "text", nil
+	case reflect.Bool:
+		return "checkbox", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number", nil
+	}
+	return "", fmt.Errorf("unsupported type %s", t)
+}
+
+// formatValue returns the form representation of a field value.
+func formatValue(v reflect.Value) string {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if v.Type() == timeType {
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(dateLayout)
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits())
+	}
+	return ""
+}
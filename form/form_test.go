@@ -0,0 +1,191 @@
+package form_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PatrickVerhagen/htmlsimple"
+	"github.com/PatrickVerhagen/htmlsimple/form"
+)
+
+func TestRenderFields(t *testing.T) {
+	born := time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		v    any
+		want string
+	}{
+		{
+			name: "text with constraints",
+			v: struct {
+				Email string `form:"email" label:"Email" input:"email" validate:"required,maxlength=100" placeholder:"you@example.com"`
+			}{"a@b.c"},
+			want: `<div class="field"><label for="input-1">Email</label><input id="input-1" maxlength="100" name="email" placeholder="you@example.com" required="" type="email" value="a@b.c" /></div>`,
+		},
+		{
+			name: "number",
+			v: struct {
+				Age int `validate:"min=18,max=130,step=1"`
+			}{20},
+			want: `<div class="field"><label for="input-1">Age</label><input id="input-1" max="130" min="18" name="Age" step="1" type="number" value="20" /></div>`,
+		},
+		{
+			name: "select",
+			v: struct {
+				Plan string `form:"plan" input:"select" options:"free,pro"`
+			}{"pro"},
+			want: `<div class="field"><label for="select-1">Plan</label><select id="select-1" name="plan"><option value=""></option><option value="free">free</option><option selected="" value="pro">pro</option></select></div>`,
+		},
+		{
+			name: "required select has no empty option",
+			v: struct {
+				Plan string `form:"plan" input:"select" options:"free,pro" validate:"required"`
+			}{},
+			want: `<div class="field"><label for="select-1">Plan</label><select id="select-1" name="plan" required=""><option value="free">free</option><option value="pro">pro</option></select></div>`,
+		},
+		{
+			name: "checkbox",
+			v: struct {
+				Terms bool `form:"terms" label:"I accept"`
+			}{true},
+			want: `<div class="field"><label for="input-1">I accept</label><input checked="" id="input-1" name="terms" type="checkbox" value="true" /></div>`,
+		},
+		{
+			name: "textarea",
+			v: struct {
+				Bio string `form:"bio" input:"textarea"`
+			}{"<b>hi</b>"},
+			want: `<div class="field"><label for="textarea-1">Bio</label><textarea id="textarea-1" name="bio">&lt;b&gt;hi&lt;/b&gt;</textarea></div>`,
+		},
+		{
+			name: "date",
+			v: &struct {
+				Born time.Time `form:"born"`
+			}{born},
+			want: `<div class="field"><label for="input-1">Born</label><input id="input-1" name="born" type="date" value="1990-05-17" /></div>`,
+		},
+		{
+			name: "hidden and skipped",
+			v: struct {
+				ID       int    `form:"id" input:"hidden"`
+				Internal string `form:"-"`
+				private  string
+			}{ID: 7},
+			want: `<input name="id" type="hidden" value="7" />`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := htmlsimple.New(nil)
+			if _, err := form.Render(g.Root, tt.v, form.Options{}); err != nil {
+				t.Fatal(err)
+			}
+			want := `<form method="post">` + tt.want + `<button type="submit">Submit</button></form>`
+			if got := g.Generate(); got != want {
+				t.Errorf("Render =\n%s\nwant\n%s", got, want)
+			}
+			if d := g.Diagnostics(); len(d) > 0 {
+				t.Errorf("unexpected diagnostics %v", d)
+			}
+		})
+	}
+}
+
+func TestRenderOptions(t *testing.T) {
+	type signup struct {
+		Name string `form:"name"`
+	}
+
+	g := htmlsimple.New(nil)
+	_, err := form.Render(g.Root, signup{}, form.Options{
+		Action: "/signup",
+		Method: "get",
+		Submit: "Join",
+		Errors: form.Errors{"name": "Name is required."},
+		CSRF:   func() (string, string) { return "csrf", "token" },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `<form action="/signup" method="get">` +
+		`<input name="csrf" type="hidden" value="token" />` +
+		`<div class="field has-error"><label for="input-1">Name</label>` +
+		`<input aria-describedby="p-1" aria-invalid="true" id="input-1" name="name" type="text" />` +
+		`<p class="error" id="p-1">Name is required.</p></div>` +
+		`<button type="submit">Join</button></form>`
+	if got := g.Generate(); got != want {
+		t.Errorf("Render =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRenderUniqueIDs(t *testing.T) {
+	type login struct {
+		Email string `form:"email" input:"email"`
+	}
+
+	tests := []struct {
+		name  string
+		build func(root *htmlsimple.Element)
+	}{
+		{
+			name: "same form twice",
+			build: func(root *htmlsimple.Element) {
+				form.Render(root, login{}, form.Options{Errors: form.Errors{"email": "Email is required."}})
+				form.Render(root, login{}, form.Options{Errors: form.Errors{"email": "Email is required."}})
+			},
+		},
+		{
+			name: "page element using a generated id",
+			build: func(root *htmlsimple.Element) {
+				root.Div().Attr("id", "input-1")
+				root.P().Attr("id", "p-1")
+				form.Render(root, login{}, form.Options{Errors: form.Errors{"email": "Email is required."}})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := htmlsimple.New(nil)
+			tt.build(g.Root)
+			if d := g.Diagnostics(); len(d) > 0 {
+				t.Errorf("diagnostics = %v, want none", d)
+			}
+			if issues := htmlsimple.CheckAccessibility(g.Root); len(issues) > 0 {
+				t.Errorf("CheckAccessibility = %v, want none", issues)
+			}
+		})
+	}
+}
+
+func TestRenderErrors(t *testing.T) {
+	var nilStruct *struct{}
+	tests := []struct {
+		name    string
+		v       any
+		wantErr string
+	}{
+		{"not a struct", 42, "int is not a struct"},
+		{"nil pointer", nilStruct, "nil *struct {}"},
+		{"unsupported type", struct{ C chan int }{}, "field C: unsupported type chan int"},
+		{"unknown rule", struct {
+			A string `validate:"sometimes"`
+		}{}, `field A: unknown validation rule "sometimes"`},
+		{"bad length", struct {
+			A string `validate:"maxlength=x"`
+		}{}, "field A: invalid maxlength"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := form.Render(htmlsimple.New(nil).Root, tt.v, form.Options{})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Render error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
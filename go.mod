@@ -1,3 +1,5 @@
 module github.com/PatrickVerhagen/htmlsimple
 
 go 1.22.6
+
+require golang.org/x/net v0.35.0
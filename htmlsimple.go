@@ -2,10 +2,12 @@
 // with built-in XSS protection and compile-time HTML structure validation.
 package htmlsimple
 
+//go:generate go run ./cmd/generate
+
 import (
 	"html"
-	"net/url"
 	"strings"
+	"sync"
 )
 
 // Tag interface represents an HTML tag with a name.
@@ -23,6 +25,17 @@ type VoidTag string
 
 func (t VoidTag) name() string { return string(t) }
 
+// SvgTag represents elements in the SVG namespace. Their names and
+// attribute names are case-sensitive, and they are self-closed when empty.
+type SvgTag string
+
+func (t SvgTag) name() string { return string(t) }
+
+// MathTag represents elements in the MathML namespace.
+type MathTag string
+
+func (t MathTag) name() string { return string(t) }
+
 // Attributes represents a map of HTML attribute key-value pairs.
 type Attributes map[string][]string
 
@@ -38,7 +51,7 @@ func KV(key, value string) KeyValue {
 }
 
 type elementI interface {
-	generateHtml(*strings.Builder)
+	generateHtml(*renderer)
 }
 
 // SanitizeFunc defines a function type for sanitizing attribute values.
@@ -56,8 +69,16 @@ type Attribute struct {
 
 // Generator is responsible for generating sanitized HTML.
 type Generator struct {
-	Root              *Element
-	allowedAttributes map[string]attributeConfig
+	Root            *Element
+	policy          *Policy
+	invalidNameMode InvalidNameMode
+	classMerger     ClassMerger
+
+	mu           sync.Mutex
+	diagnostics  []Diagnostic
+	placeholders map[string][]*Element
+	ids          map[string][]*Element
+	idCounters   map[string]int
 }
 
 // Element represents an HTML element with tag, attributes, children, and content.
@@ -68,125 +89,126 @@ type Element struct {
 	Parent     *Element
 	Content    string
 	generator  *Generator
-}
 
-// New initializes a new Generator with default allowed attributes and sanitization functions.
-func New(allowedAttributesCustom []Attribute) *Generator {
-	g := &Generator{
-		allowedAttributes: make(map[string]attributeConfig),
-	}
+	// placeholder is the name of a placeholder element, and filled whether
+	// Generator.Fill has replaced its default content.
+	placeholder string
+	filled      bool
 
-	defaultAllowed := []string{
-		"accept", "accept-charset", "accesskey", "allow", "alt", "as", "async",
-		"autocapitalize", "autocomplete", "autoplay", "background", "bgcolor", "border",
-		"capture", "charset", "checked", "cite", "class", "color", "cols", "colspan",
-		"content", "contenteditable", "controls", "coords", "crossorigin", "data", "data-*",
-		"datetime", "decoding", "default", "defer", "dir", "dirname", "disabled", "download",
-		"draggable", "enctype", "enterkeyhint", "for", "form", "formenctype",
-		"formmethod", "formnovalidate", "formtarget", "headers", "height", "hidden", "high",
-		"hreflang", "http-equiv", "id", "integrity", "inputmode", "ismap", "itemprop",
-		"kind", "label", "lang", "loading", "list", "loop", "low", "max", "maxlength",
-		"minlength", "media", "method", "min", "multiple", "muted", "name", "novalidate",
-		"open", "optimum", "pattern", "placeholder", "playsinline",
-		"preload", "readonly", "referrerpolicy", "rel", "required", "reversed", "role",
-		"rows", "rowspan", "sandbox", "scope", "selected", "shape", "size", "sizes",
-		"slot", "span", "spellcheck", "srcdoc", "srclang", "start", "step",
-		"style", "tabindex", "target", "title", "translate", "type", "usemap", "value",
-		"width", "wrap",
-	}
+	// stylesheets are those attached with UseStylesheet.
+	stylesheets []*Stylesheet
+}
 
-	defaultAllowedUrl := []string{
-		"href", "src", "action", "formaction", "srcset", "ping", "poster",
-	}
+// VoidElement is an element created from a VoidTag, such as <br> or <img>.
+// It can carry attributes but, unlike Element, has no way to add children or
+// text, which void elements can never render.
+type VoidElement struct {
+	element *Element
+}
 
-	defaultAllowedHtmx := []string{
-		"hx-get", "hx-post", "hx-put", "hx-patch", "hx-delete",
+// New initializes a new Generator with default allowed attributes and sanitization functions.
+// Without custom attributes it shares the package's default Policy, so
+// creating a Generator per request is cheap.
+func New(allowedAttributesCustom []Attribute) *Generator {
+	if allowedAttributesCustom == nil {
+		return NewWithPolicy(DefaultPolicy())
 	}
-	defaultAllowedUrl = append(defaultAllowedUrl, defaultAllowedHtmx...)
+	return NewWithPolicy(NewPolicy(allowedAttributesCustom))
+}
 
-	for _, attr := range defaultAllowed {
-		if allowedAttributesCustom != nil {
-			if _, exists := g.allowedAttributes[attr]; exists {
-				continue
-			}
-		}
-		g.allowedAttributes[attr] = attributeConfig{allowed: true, sanitizeFunc: html.EscapeString}
-	}
-	urlSanitizeFunc := func(s string) string {
-		u, err := url.Parse(s)
-		if err != nil {
-			return "#"
-		}
-		if u.Scheme == "javascript" {
-			return "#"
-		}
-		if u.Scheme == "" && !strings.HasPrefix(u.Path, "/") {
-			return "#"
-		}
-		return html.EscapeString(u.String())
-	}
+// NewWithPolicy initializes a new Generator that uses p. A Policy is never
+// modified, so many generators, each used by its own goroutine, can share
+// one.
+//
+// Example:
+//
+//	policy := htmlsimple.NewPolicy([]htmlsimple.Attribute{{Name: "hx-target"}})
+//	g := htmlsimple.NewWithPolicy(policy)
+func NewWithPolicy(p *Policy) *Generator {
+	g := &Generator{policy: p}
+	g.Root = &Element{Tag: NormalTag(""), generator: g}
+	return g
+}
 
-	for _, attr := range defaultAllowedUrl {
-		if allowedAttributesCustom != nil {
-			if _, exists := g.allowedAttributes[attr]; exists {
-				continue
-			}
-			if allowedAttributesCustom != nil {
-				if _, exists := g.allowedAttributes[attr]; exists {
-					continue
-				}
-			}
-			g.allowedAttributes[attr] = attributeConfig{allowed: true, sanitizeFunc: urlSanitizeFunc}
-		}
+// Add creates and adds a child NormalTag element to the current element.
+func (e *Element) Add(tag NormalTag) *Element {
+	return e.addChild(tag)
+}
 
-		for _, attribute := range allowedAttributesCustom {
-			g.allowedAttributes[attribute.Name] = attributeConfig{allowed: true, sanitizeFunc: html.EscapeString}
-		}
+// AddVoid creates and adds a child VoidTag element to the current element.
+func (e *Element) AddVoid(tag VoidTag) *VoidElement {
+	return &VoidElement{element: e.addChild(tag)}
+}
 
-	}
+// Attr sets a single attribute on the void element. See Element.Attr.
+func (v *VoidElement) Attr(key, value string) *VoidElement {
+	v.element.setAttribute(key, value)
+	return v
+}
 
-	g.Root = &Element{
-		Tag:        NormalTag(""),
-		Children:   []elementI{},
-		Attributes: make(Attributes),
-		generator:  g,
-	}
+// WithAttrs sets multiple attributes on the void element. See
+// Element.WithAttrs.
+func (v *VoidElement) WithAttrs(attrs ...KeyValue) *VoidElement {
+	v.element.WithAttrs(attrs...)
+	return v
+}
 
-	return g
+// Parent returns the element the void element was added to, so a chain
+// can continue with its siblings.
+func (v *VoidElement) Parent() *Element {
+	return v.element.Parent
 }
 
-func (g *Generator) _allowAttribute(name string, sanitizeFunc sanitizeFunc) {
-	g.allowedAttributes[name] = attributeConfig{allowed: true, sanitizeFunc: sanitizeFunc}
+// Path returns the location of the void element in its tree. See
+// Element.Path.
+func (v *VoidElement) Path() string {
+	return v.element.Path()
 }
 
-// Add creates and adds a child NormalTag element to the current element.
-func (e *Element) Add(tag NormalTag) *Element {
-	child := &Element{
-		Tag:        tag,
-		Attributes: make(Attributes),
-		Children:   []elementI{},
-		Parent:     e,
-		generator:  e.generator,
+// addChild creates a child element with tag. If the tag name is invalid the
+// child is handled according to the generator's InvalidNameMode; when it is
+// dropped, the child is returned detached so the chain can continue.
+func (e *Element) addChild(tag Tag) *Element {
+	child := newElement(tag, e)
+
+	if !e.generator.checkName(e, "element", tag.name(), validElementName) {
+		return child
+	}
+	if e.isVoid() {
+		e.generator.report(e, SeverityError, "<%s> added to void element is not rendered", tag.name())
 	}
 	e.Children = append(e.Children, child)
 	return child
 }
 
-// AddVoid creates and adds a child VoidTag element to the current element.
-func (e *Element) AddVoid(tag VoidTag) *Element {
-	child := &Element{
-		Tag:        tag,
-		Attributes: make(Attributes),
-		Parent:     e,
-		generator:  e.generator,
-	}
-	e.Children = append(e.Children, child)
-	return child
+// AddSvg creates and adds a child SvgTag element to the current element.
+func (e *Element) AddSvg(tag SvgTag) *Element {
+	return e.addChild(tag)
+}
+
+// AddMath creates and adds a child MathTag element to the current element.
+func (e *Element) AddMath(tag MathTag) *Element {
+	return e.addChild(tag)
+}
+
+// AddAnnotation adds an <annotation> with the given encoding to the current
+// element, normally a <semantics>. The content is escaped like AddString,
+// so TeX or other source can be attached as-is. It returns the current
+// element so several annotations can be chained.
+//
+// Example:
+//
+//	sem := element.Math().Semantics()
+//	sem.Mfrac() // ...
+//	sem.AddAnnotation("application/x-tex", `\frac{1}{2}`)
+func (e *Element) AddAnnotation(encoding, content string) *Element {
+	e.AddMath(MathTag("annotation")).Attr("encoding", encoding).AddString(content)
+	return e
 }
 
 // Attr sets a single attribute on the current element.
 // For 'class' and 'style' attributes, values are concatenated:
-// - 'class' values are space-separated
+// - 'class' values are space-separated, and classes already present are skipped
 // - 'style' values are semicolon-separated (if no semicolon is added, it will be added for you)
 // For other attributes, the value is replaced.
 //
@@ -207,7 +229,7 @@ func (e *Element) Attr(key, value string) *Element {
 
 // WithAttrs sets multiple attributes on the current element using KeyValue pairs.
 // For 'class' and 'style' attributes, values are concatenated:
-// - 'class' values are space-separated
+// - 'class' values are space-separated, and classes already present are skipped
 // - 'style' values are semicolon-separated (if no semicolon is added, it will be added for you)
 // For other attributes, the value is replaced.
 //
@@ -229,8 +251,8 @@ func (e *Element) WithAttrs(attrs ...KeyValue) *Element {
 }
 
 // setAttribute handles attribute setting with special behavior for certain attributes:
-//   - 'class' attributes are concatenated (space-separated)
-//     Example: .Attr("class", "btn").Attr("class", "primary") results in class="btn primary"
+//   - 'class' attributes are concatenated (space-separated) without duplicates
+//     Example: .Attr("class", "btn").Attr("class", "primary btn") results in class="btn primary"
 //   - 'style' attributes are appended
 //     Example: .Attr("style", "color: red;").Attr("style", "font-size: 12px;") results in style="color: red; font-size: 12px;"
 //   - Other attributes are replaced entirely
@@ -239,21 +261,44 @@ func (e *Element) WithAttrs(attrs ...KeyValue) *Element {
 // Non-allowed attributes are prefixed with 'data-' for safety
 //
 //	Example: .Attr("onclick", "alert('Hi')") results in data-onclick="alert('Hi')"
+//
+// Attribute names that are not valid HTML names are rejected according to
+// the generator's InvalidNameMode.
+//
+// SVG and MathML elements are checked against their own allowlists, and
+// custom elements additionally accept the attributes they declare. A slot
+// that the parent custom element does not declare is treated as not allowed.
 func (e *Element) setAttribute(key, value string) {
-	config, exists := e.generator.allowedAttributes[key]
-	if exists && config.allowed {
+	if !e.generator.checkName(e, "attribute", key, validAttributeName) {
+		return
+	}
+
+	if e.Attributes == nil {
+		e.Attributes = make(Attributes)
+	}
+
+	config, exists := e.lookupAttribute(key)
+	slotAllowed := key != "slot" || e.slotAllowed(value)
+	if exists && config.allowed && slotAllowed {
 		sanitizedValue := value
 		if config.sanitizeFunc != nil {
 			sanitizedValue = config.sanitizeFunc(value)
 		} else {
 			sanitizedValue = html.EscapeString(value)
 		}
+		if sanitizedValue == "#" && value != "#" {
+			e.generator.report(e, SeverityWarning, "unsafe URL %q in %s replaced with #", value, key)
+		}
 
 		switch key {
 		case "class":
-			e.Attributes[key] = append(e.Attributes[key], strings.Fields(sanitizedValue)...)
+			e.appendClasses(sanitizedValue)
 		case "style":
 			e.Attributes[key] = append(e.Attributes[key], sanitizedValue)
+		case "id":
+			old := e.Attributes[key]
+			e.Attributes[key] = []string{sanitizedValue}
+			e.generator.trackID(e, old, sanitizedValue)
 		default:
 			e.Attributes[key] = []string{sanitizedValue}
 		}
@@ -262,63 +307,47 @@ func (e *Element) setAttribute(key, value string) {
 	} else if strings.HasPrefix(key, "data-") {
 		e.Attributes[key] = []string{html.EscapeString(value)}
 	} else {
+		if !slotAllowed {
+			e.generator.report(e, SeverityWarning, "slot %q is not declared by <%s>, rendered as data-slot", value, e.outerParent().Tag.name())
+		} else {
+			e.generator.report(e, SeverityWarning, "attribute %q is not allowed, rendered as data-%s", key, key)
+		}
 		e.Attributes["data-"+key] = []string{html.EscapeString(value)}
 	}
 }
 
-// AddString adds sanitized text content to the current element.
-func (e *Element) AddString(content string) *Element {
-	e.Content += html.EscapeString(content)
-	return e
-}
-
-func (e *Element) generateHtml(builder *strings.Builder) {
-	if e.Tag.name() == "" {
-		for _, child := range e.Children {
-			child.generateHtml(builder)
-		}
-		return
+// lookupAttribute returns how the attribute key is handled on e.
+// The generator's policy comes first, so a custom element that declares
+// href or src does not switch off their URL sanitizing.
+func (e *Element) lookupAttribute(key string) (attributeConfig, bool) {
+	if config, exists := e.generator.attributePolicy(e.Tag)[key]; exists {
+		return config, true
 	}
-
-	builder.WriteString("<")
-	builder.WriteString(e.Tag.name())
-
-	for k, v := range e.Attributes {
-		builder.WriteString(" ")
-		builder.WriteString(k)
-		builder.WriteString(`="`)
-		switch k {
-		case "class":
-			builder.WriteString(strings.Join(v, " "))
-		default:
-			builder.WriteString(strings.Join(v, " "))
+	if c, ok := e.Tag.(*CustomElement); ok {
+		if config, exists := c.attributes[key]; exists {
+			return config, true
 		}
-		builder.WriteString(`"`)
-	}
-
-	if _, isVoid := e.Tag.(VoidTag); isVoid {
-		builder.WriteString(" />")
-		return
-	}
-
-	builder.WriteString(">")
-
-	if e.Content != "" {
-		builder.WriteString(e.Content)
 	}
+	config, exists := e.generator.elementPolicy(e.Tag)[key]
+	return config, exists
+}
 
-	for _, child := range e.Children {
-		child.generateHtml(builder)
+// AddString adds sanitized text content to the current element.
+func (e *Element) AddString(content string) *Element {
+	if e.isVoid() {
+		e.generator.report(e, SeverityError, "text added to void element is not rendered")
 	}
-
-	builder.WriteString("</")
-	builder.WriteString(e.Tag.name())
-	builder.WriteString(">")
+	e.Content += html.EscapeString(content)
+	return e
 }
 
 // Generate returns the complete sanitized HTML string.
 func (g *Generator) Generate() string {
-	var builder strings.Builder
-	g.Root.generateHtml(&builder)
-	return builder.String()
+	return g.GenerateWith(RenderOptions{})
+}
+
+// GenerateWith returns the complete sanitized HTML string, serialized
+// according to opts.
+func (g *Generator) GenerateWith(opts RenderOptions) string {
+	return g.Root.Render(opts)
 }
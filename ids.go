@@ -0,0 +1,149 @@
+package htmlsimple
+
+import (
+	"html"
+	"slices"
+	"strconv"
+)
+
+// Node is an element that can be referenced by another, such as the target
+// of Element.For. Both *Element and *VoidElement are nodes.
+type Node interface {
+	node() *Element
+}
+
+func (e *Element) node() *Element     { return e }
+func (v *VoidElement) node() *Element { return v.element }
+
+// trackID records that e changed its id from old to id, and reports an id
+// that another element already uses.
+func (g *Generator) trackID(e *Element, old []string, id string) {
+	g.mu.Lock()
+	if g.ids == nil {
+		g.ids = make(map[string][]*Element)
+	}
+	if len(old) > 0 {
+		g.untrackID(e, old[0])
+	}
+	users := g.ids[id]
+	duplicate := len(users) > 0 && !slices.Contains(users, e)
+	if !slices.Contains(users, e) {
+		g.ids[id] = append(users, e)
+	}
+	g.mu.Unlock()
+
+	if duplicate {
+		g.report(e, SeverityWarning, "id %q is already used by %s", id, users[0].Path())
+	}
+}
+
+// untrackID records that e no longer uses id. The caller holds g.mu.
+func (g *Generator) untrackID(e *Element, id string) {
+	users := g.ids[id]
+	i := slices.Index(users, e)
+	if i < 0 {
+		return
+	}
+	if len(users) == 1 {
+		delete(g.ids, id)
+		return
+	}
+	g.ids[id] = slices.Delete(users, i, i+1)
+}
+
+// UniqueID returns an id made from prefix that no element built by g uses
+// yet, such as "email-1", and reserves it. It is safe to call from several
+// goroutines.
+func (g *Generator) UniqueID(prefix string) string {
+	if prefix == "" {
+		prefix = "id"
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.ids == nil {
+		g.ids = make(map[string][]*Element)
+	}
+	if g.idCounters == nil {
+		g.idCounters = make(map[string]int)
+	}
+	for {
+		g.idCounters[prefix]++
+		id := prefix + "-" + strconv.Itoa(g.idCounters[prefix])
+		if _, used := g.ids[id]; !used {
+			// Reserve the id until an element takes it.
+			g.ids[id] = nil
+			return id
+		}
+	}
+}
+
+// ID returns the id of the current element, first giving it a unique one
+// named after its tag if it has none.
+func (e *Element) ID() string {
+	if id, ok := e.Attributes["id"]; ok && len(id) > 0 {
+		// Attribute values are stored escaped.
+		return html.UnescapeString(id[0])
+	}
+	id := e.generator.UniqueID(e.Tag.name())
+	e.Attr("id", id)
+	return id
+}
+
+// ID returns the id of the void element, first giving it a unique one if it
+// has none. See Element.ID.
+func (v *VoidElement) ID() string {
+	return v.element.ID()
+}
+
+// For points the for attribute of the current element, normally a <label>
+// or <output>, at target and returns the current element. Target is given
+// an id if it has none.
+//
+// Example:
+//
+//	input := form.Input().Attr("name", "email")
+//	form.Label().For(input).AddString("Email")
+func (e *Element) For(target Node) *Element {
+	return e.Attr("for", target.node().ID())
+}
+
+// LabelledBy sets aria-labelledby of the current element to the ids of
+// targets, giving them ids where needed, and returns the current element.
+func (e *Element) LabelledBy(targets ...Node) *Element {
+	return e.AriaLabelledBy(nodeIDs(targets)...)
+}
+
+// DescribedBy sets aria-describedby of the current element to the ids of
+// targets, giving them ids where needed, and returns the current element.
+func (e *Element) DescribedBy(targets ...Node) *Element {
+	return e.AriaDescribedBy(nodeIDs(targets)...)
+}
+
+// Controls sets aria-controls of the current element to the ids of
+// targets, giving them ids where needed, and returns the current element.
+func (e *Element) Controls(targets ...Node) *Element {
+	return e.AriaControls(nodeIDs(targets)...)
+}
+
+// LabelledBy sets aria-labelledby of the void element. See
+// Element.LabelledBy.
+func (v *VoidElement) LabelledBy(targets ...Node) *VoidElement {
+	v.element.LabelledBy(targets...)
+	return v
+}
+
+// DescribedBy sets aria-describedby of the void element. See
+// Element.DescribedBy.
+func (v *VoidElement) DescribedBy(targets ...Node) *VoidElement {
+	v.element.DescribedBy(targets...)
+	return v
+}
+
+func nodeIDs(targets []Node) []string {
+	ids := make([]string, 0, len(targets))
+	for _, target := range targets {
+		ids = append(ids, target.node().ID())
+	}
+	return ids
+}
@@ -0,0 +1,233 @@
+package htmlsimple
+
+import "testing"
+
+func TestDuplicateIDs(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(g *Generator)
+		warns int
+	}{
+		{
+			name: "duplicate",
+			build: func(g *Generator) {
+				g.Root.Div().Attr("id", "main")
+				g.Root.Span().Attr("id", "main")
+			},
+			warns: 1,
+		},
+		{
+			name: "same element again",
+			build: func(g *Generator) {
+				g.Root.Div().Attr("id", "main").Attr("id", "main")
+			},
+		},
+		{
+			name: "renamed",
+			build: func(g *Generator) {
+				g.Root.Div().Attr("id", "main").Attr("id", "content")
+				g.Root.Span().Attr("id", "main")
+			},
+		},
+		{
+			name: "third user after first renamed",
+			build: func(g *Generator) {
+				first := g.Root.Div().Attr("id", "main")
+				g.Root.Span().Attr("id", "main")
+				first.Attr("id", "other")
+				g.Root.P().Attr("id", "main")
+			},
+			warns: 2,
+		},
+		{
+			name: "default content replaced by fill",
+			build: func(g *Generator) {
+				g.Root.Placeholder("main").Div().Attr("id", "content").Span().Attr("id", "inner")
+				g.Fill("main", func(p *Element) {
+					p.Div().Attr("id", "content")
+					p.Span().Attr("id", "inner")
+				})
+			},
+		},
+		{
+			name: "later fill adds",
+			build: func(g *Generator) {
+				g.Root.Placeholder("main")
+				for range 2 {
+					g.Fill("main", func(p *Element) {
+						p.Div().Attr("id", "content")
+					})
+				}
+			},
+			warns: 1,
+		},
+		{
+			name: "moved within the tree",
+			build: func(g *Generator) {
+				div := g.Root.Div().Attr("id", "main")
+				g.Root.Section().Append(div)
+				g.Root.Span().Attr("id", "main")
+			},
+			warns: 1,
+		},
+		{
+			name: "moved from another generator",
+			build: func(g *Generator) {
+				other := New(nil)
+				div := other.Root.Div().Attr("id", "main")
+				g.Root.Append(div)
+				other.Root.Span().Attr("id", "main")
+				g.Root.P().Attr("id", "main")
+				if n := len(other.Diagnostics()); n != 0 {
+					t.Errorf("other generator diagnostics = %v, want none", other.Diagnostics())
+				}
+			},
+			warns: 1,
+		},
+		{
+			name: "fragment from another generator",
+			build: func(g *Generator) {
+				g.Root.Div().Attr("id", "main")
+				f := New(nil).Fragment()
+				f.Span().Attr("id", "main")
+				g.Root.Append(f)
+			},
+			warns: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(nil)
+			tt.build(g)
+			if n := len(g.Diagnostics()); n != tt.warns {
+				t.Errorf("diagnostics = %v, want %d warnings", g.Diagnostics(), tt.warns)
+			}
+		})
+	}
+}
+
+func TestUniqueID(t *testing.T) {
+	tests := []struct {
+		name   string
+		build  func(g *Generator)
+		prefix string
+		want   string
+	}{
+		{name: "first", prefix: "email", want: "email-1"},
+		{name: "empty prefix", prefix: "", want: "id-1"},
+		{
+			name:   "counts per prefix",
+			build:  func(g *Generator) { g.UniqueID("email"); g.UniqueID("name") },
+			prefix: "email",
+			want:   "email-2",
+		},
+		{
+			name:   "skips ids in use",
+			build:  func(g *Generator) { g.Root.Div().Attr("id", "email-1") },
+			prefix: "email",
+			want:   "email-2",
+		},
+		{
+			name: "reuses ids of replaced default content",
+			build: func(g *Generator) {
+				g.Root.Placeholder("form").Input().Attr("id", "email-1")
+				g.Fill("form", func(*Element) {})
+			},
+			prefix: "email",
+			want:   "email-1",
+		},
+		{
+			name: "reuses ids moved to another generator",
+			build: func(g *Generator) {
+				div := g.Root.Div().Attr("id", "email-1")
+				New(nil).Root.Append(div)
+			},
+			prefix: "email",
+			want:   "email-1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(nil)
+			if tt.build != nil {
+				tt.build(g)
+			}
+			if got := g.UniqueID(tt.prefix); got != tt.want {
+				t.Errorf("UniqueID(%q) = %q, want %q", tt.prefix, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIDReferences(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(form *Element)
+		want  string
+	}{
+		{
+			name: "id given",
+			build: func(form *Element) {
+				form.Label().AddString(form.Input().ID())
+			},
+			want: `<form><label>input-1</label><input id="input-1" /></form>`,
+		},
+		{
+			name: "existing id kept",
+			build: func(form *Element) {
+				form.Span().Attr("id", "a&b").AddString(form.Children[0].(*Element).ID())
+			},
+			want: `<form><span id="a&amp;b">a&amp;b</span></form>`,
+		},
+		{
+			name: "for",
+			build: func(form *Element) {
+				input := form.Input().Attr("name", "email")
+				form.Label().For(input).AddString("Email")
+			},
+			want: `<form><input id="input-1" name="email" /><label for="input-1">Email</label></form>`,
+		},
+		{
+			name: "labelled and described by",
+			build: func(form *Element) {
+				title := form.H2().AddString("Sign up")
+				hint := form.P().AddString("All fields are required")
+				form.Div().LabelledBy(title).DescribedBy(hint, title)
+			},
+			want: `<form><h2 id="h2-1">Sign up</h2><p id="p-1">All fields are required</p><div aria-describedby="p-1 h2-1" aria-labelledby="h2-1"></div></form>`,
+		},
+		{
+			name: "controls",
+			build: func(form *Element) {
+				menu := form.Ul()
+				form.Button().Controls(menu).AddString("Menu")
+			},
+			want: `<form><ul id="ul-1"></ul><button aria-controls="ul-1">Menu</button></form>`,
+		},
+		{
+			name: "void element",
+			build: func(form *Element) {
+				hint := form.Span().AddString("hint")
+				title := form.Span().AddString("title")
+				form.Input().LabelledBy(title).DescribedBy(hint)
+			},
+			want: `<form><span id="span-2">hint</span><span id="span-1">title</span><input aria-describedby="span-2" aria-labelledby="span-1" /></form>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(nil)
+			form := g.Root.Form()
+			tt.build(form)
+			if got := g.Generate(); got != tt.want {
+				t.Errorf("Generate = %s, want %s", got, tt.want)
+			}
+			if d := g.Diagnostics(); len(d) > 0 {
+				t.Errorf("diagnostics = %v, want none", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package htmlsimple
+
+// Each calls fn with parent and every item of items, so repeated content
+// can be built without breaking the chain. It returns parent.
+//
+// Example:
+//
+//	Each(element.Ul(), users, func(ul *Element, u User) {
+//		ul.Li().AddString(u.Name)
+//	})
+func Each[T any](parent *Element, items []T, fn func(*Element, T)) *Element {
+	for _, item := range items {
+		fn(parent, item)
+	}
+	return parent
+}
+
+// Map returns fn applied to every item of items. Together with Items it
+// turns a slice into list items:
+//
+//	element.Ul().Items(Map(users, func(u User) string { return u.Name })...)
+func Map[T, R any](items []T, fn func(T) R) []R {
+	result := make([]R, 0, len(items))
+	for _, item := range items {
+		result = append(result, fn(item))
+	}
+	return result
+}
+
+// If calls fn with the current element when cond is true. It returns the
+// current element, so the chain continues either way.
+//
+// Example:
+//
+//	element.Div().If(user.Admin, func(d *Element) {
+//		d.Attr("class", "admin")
+//	}).AddString(user.Name)
+func (e *Element) If(cond bool, fn func(*Element)) *Element {
+	if cond {
+		fn(e)
+	}
+	return e
+}
+
+// IfElse calls then with the current element when cond is true and
+// otherwise calls otherwise. It returns the current element.
+func (e *Element) IfElse(cond bool, then, otherwise func(*Element)) *Element {
+	if cond {
+		then(e)
+	} else {
+		otherwise(e)
+	}
+	return e
+}
+
+// Items adds an <li> with the escaped text of every item to the current
+// element, normally a <ul>, <ol> or <menu>. It returns the current element.
+func (e *Element) Items(items ...string) *Element {
+	for _, item := range items {
+		e.Li().AddString(item)
+	}
+	return e
+}
@@ -0,0 +1,85 @@
+package htmlsimple
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestIterationHelpers(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(root *Element)
+		want  string
+	}{
+		{
+			name: "each",
+			build: func(root *Element) {
+				Each(root.Ol(), []int{1, 2}, func(ol *Element, n int) {
+					ol.Li().AddString(strconv.Itoa(n * 10))
+				}).Attr("class", "numbers")
+			},
+			want: `<ol class="numbers"><li>10</li><li>20</li></ol>`,
+		},
+		{
+			name: "each with no items",
+			build: func(root *Element) {
+				Each(root.Ul(), []string(nil), func(ul *Element, s string) { ul.Li() })
+			},
+			want: `<ul></ul>`,
+		},
+		{
+			name: "map and items",
+			build: func(root *Element) {
+				root.Ul().Items(Map([]int{1, 2}, strconv.Itoa)...)
+			},
+			want: `<ul><li>1</li><li>2</li></ul>`,
+		},
+		{
+			name: "items are escaped",
+			build: func(root *Element) {
+				root.Menu().Items("<b>", "a & b")
+			},
+			want: `<menu><li>&lt;b&gt;</li><li>a &amp; b</li></menu>`,
+		},
+		{
+			name: "if true",
+			build: func(root *Element) {
+				root.Div().If(true, func(d *Element) { d.Attr("class", "admin") }).AddString("x")
+			},
+			want: `<div class="admin">x</div>`,
+		},
+		{
+			name: "if false",
+			build: func(root *Element) {
+				root.Div().If(false, func(d *Element) { d.Attr("class", "admin") }).AddString("x")
+			},
+			want: `<div>x</div>`,
+		},
+		{
+			name: "if else",
+			build: func(root *Element) {
+				for _, cond := range []bool{true, false} {
+					root.P().IfElse(cond,
+						func(p *Element) { p.AddString("yes") },
+						func(p *Element) { p.AddString("no") },
+					)
+				}
+			},
+			want: `<p>yes</p><p>no</p>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(nil)
+			tt.build(g.Root)
+			if got := g.Generate(); got != tt.want {
+				t.Errorf("Generate = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := Map([]int(nil), strconv.Itoa); got == nil || len(got) != 0 {
+		t.Errorf("Map(nil) = %#v, want empty slice", got)
+	}
+}
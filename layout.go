@@ -0,0 +1,100 @@
+package htmlsimple
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Placeholder adds a named placeholder to the current element and returns
+// it. A placeholder has no tag of its own: whatever is added to it
+// directly, text or elements, is the default content, shown until the
+// placeholder is filled with Generator.Fill. This lets one function build
+// the base layout of many pages while each page supplies its own content.
+//
+// Example:
+//
+//	func layout(g *htmlsimple.Generator, title string) {
+//		html := g.Root.Html()
+//		html.Head().Title().AddString(title)
+//		body := html.Body()
+//		body.Placeholder("nav").P().AddString("No navigation")
+//		body.Placeholder("content")
+//	}
+//
+//	layout(g, "Orders")
+//	err := g.Fill("content", func(content *htmlsimple.Element) {
+//		content.H1().AddString("Orders")
+//	})
+func (e *Element) Placeholder(name string) *Element {
+	p := &Element{
+		Tag:         NormalTag(""),
+		Parent:      e,
+		generator:   e.generator,
+		placeholder: name,
+	}
+	e.Children = append(e.Children, p)
+
+	g := e.generator
+	g.mu.Lock()
+	g.addPlaceholder(p)
+	g.mu.Unlock()
+	return p
+}
+
+// addPlaceholder registers p so Fill finds it. The caller holds g.mu.
+func (g *Generator) addPlaceholder(p *Element) {
+	if g.placeholders == nil {
+		g.placeholders = make(map[string][]*Element)
+	}
+	g.placeholders[p.placeholder] = append(g.placeholders[p.placeholder], p)
+}
+
+// removePlaceholder undoes addPlaceholder. The caller holds g.mu.
+func (g *Generator) removePlaceholder(p *Element) {
+	kept := slices.DeleteFunc(slices.Clone(g.placeholders[p.placeholder]), func(other *Element) bool {
+		return other == p
+	})
+	if len(kept) == 0 {
+		delete(g.placeholders, p.placeholder)
+		return
+	}
+	g.placeholders[p.placeholder] = kept
+}
+
+// Fill calls fn with every placeholder named name. The first fill replaces
+// the default content, whose ids become free again; later fills of the same
+// name add to it, so several components can contribute to one placeholder.
+// It returns an error if no placeholder with that name was added.
+func (g *Generator) Fill(name string, fn func(*Element)) error {
+	g.mu.Lock()
+	placeholders := g.placeholders[name]
+	g.mu.Unlock()
+	if len(placeholders) == 0 {
+		return fmt.Errorf("htmlsimple: no placeholder named %q", name)
+	}
+
+	for _, p := range placeholders {
+		if !p.filled {
+			for _, c := range p.Children {
+				if el, ok := c.(*Element); ok {
+					g.forget(el)
+				}
+			}
+			p.Children = nil
+			p.Content = ""
+			p.filled = true
+		}
+		fn(p)
+	}
+	return nil
+}
+
+// outerParent returns the parent of e, looking through placeholders, which
+// do not appear in the output.
+func (e *Element) outerParent() *Element {
+	p := e.Parent
+	for p != nil && p.placeholder != "" {
+		p = p.Parent
+	}
+	return p
+}
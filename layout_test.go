@@ -0,0 +1,192 @@
+package htmlsimple
+
+import "testing"
+
+func TestPlaceholders(t *testing.T) {
+	layout := func(g *Generator) {
+		html := g.Root.Html()
+		html.Head().Title().Placeholder("title").AddString("Default")
+		body := html.Body()
+		body.Placeholder("nav").P().AddString("No navigation")
+		body.Placeholder("content")
+	}
+
+	tests := []struct {
+		name string
+		opts RenderOptions
+		fill func(g *Generator) error
+		want string
+	}{
+		{
+			name: "default content",
+			want: `<html><head><title>Default</title></head><body><p>No navigation</p></body></html>`,
+		},
+		{
+			name: "filled text",
+			fill: func(g *Generator) error {
+				return g.Fill("title", func(p *Element) { p.AddString("Orders & more") })
+			},
+			want: `<html><head><title>Orders &amp; more</title></head><body><p>No navigation</p></body></html>`,
+		},
+		{
+			name: "filled elements replace the default",
+			fill: func(g *Generator) error {
+				return g.Fill("nav", func(p *Element) { p.Nav().A().Attr("href", "/").AddString("Home") })
+			},
+			want: `<html><head><title>Default</title></head><body><nav><a href="/">Home</a></nav></body></html>`,
+		},
+		{
+			name: "later fills add to the first",
+			fill: func(g *Generator) error {
+				for _, s := range []string{"one", "two"} {
+					if err := g.Fill("content", func(p *Element) { p.P().AddString(s) }); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			want: `<html><head><title>Default</title></head><body><p>No navigation</p><p>one</p><p>two</p></body></html>`,
+		},
+		{
+			name: "pretty",
+			opts: RenderOptions{Pretty: true},
+			fill: func(g *Generator) error {
+				return g.Fill("title", func(p *Element) { p.AddString("Orders") })
+			},
+			want: "<html>\n  <head>\n    <title>Orders</title>\n  </head>\n  <body>\n    <p>No navigation</p>\n  </body>\n</html>\n",
+		},
+		{
+			name: "pretty default text",
+			opts: RenderOptions{Pretty: true},
+			want: "<html>\n  <head>\n    <title>Default</title>\n  </head>\n  <body>\n    <p>No navigation</p>\n  </body>\n</html>\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(nil)
+			layout(g)
+			if tt.fill != nil {
+				if err := tt.fill(g); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := g.GenerateWith(tt.opts); got != tt.want {
+				t.Errorf("GenerateWith =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaceholderInlinePretty(t *testing.T) {
+	g := New(nil)
+	p := g.Root.Div().P().AddString("Hello,")
+	name := p.Placeholder("name")
+	name.AddString(" guest")
+	name.Em().AddString("!")
+
+	want := "<div>\n  <p>Hello, guest<em>!</em></p>\n</div>\n"
+	if got := g.GenerateWith(RenderOptions{Pretty: true}); got != want {
+		t.Errorf("GenerateWith = %q, want %q", got, want)
+	}
+}
+
+func TestFillEveryPlaceholderOfAName(t *testing.T) {
+	g := New(nil)
+	g.Root.Header().Placeholder("user")
+	g.Root.Footer().Placeholder("user").AddString("guest")
+	if err := g.Fill("user", func(p *Element) { p.AddString("Ann") }); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := g.Generate(), `<header>Ann</header><footer>Ann</footer>`; got != want {
+		t.Errorf("Generate = %q, want %q", got, want)
+	}
+}
+
+func TestFillUnknownPlaceholder(t *testing.T) {
+	g := New(nil)
+	g.Root.Placeholder("content")
+	err := g.Fill("sidebar", func(*Element) { t.Error("fn called for unknown placeholder") })
+	if err == nil || err.Error() != `htmlsimple: no placeholder named "sidebar"` {
+		t.Errorf("Fill error = %v", err)
+	}
+}
+
+func TestPlaceholderPathsAndParents(t *testing.T) {
+	g := New(nil)
+	widget := MustCustomElement("my-card", nil, []string{"title"})
+	card := g.Root.Main().AddCustom(widget)
+	span := card.Placeholder("title").Span().Attr("slot", "title").Attr("onclick", "x")
+
+	if got, want := span.Path(), "main > my-card > span"; got != want {
+		t.Errorf("Path = %q, want %q", got, want)
+	}
+	// The slot is checked against the custom element around the placeholder.
+	if d := g.Diagnostics(); len(d) != 1 || d[0].Reason != `attribute "onclick" is not allowed, rendered as data-onclick` {
+		t.Errorf("Diagnostics = %v", d)
+	}
+}
+
+func TestPlaceholdersMoveWithAppend(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(from, to *Generator)
+		want  string
+	}{
+		{
+			name: "fragment",
+			build: func(from, to *Generator) {
+				layout := from.Fragment()
+				layout.Main().Placeholder("content").AddString("default")
+				to.Root.Append(layout)
+			},
+			want: "<main>filled</main>",
+		},
+		{
+			name: "element",
+			build: func(from, to *Generator) {
+				main := from.Root.Main()
+				main.Placeholder("content").AddString("default")
+				to.Root.Append(main)
+			},
+			want: "<main>filled</main>",
+		},
+		{
+			name: "nested",
+			build: func(from, to *Generator) {
+				section := from.Root.Section()
+				section.Div().Placeholder("content").P().Placeholder("content")
+				to.Root.Append(section)
+			},
+			want: "<section><div>filled</div></section>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to := New(nil), New(nil)
+			tt.build(from, to)
+
+			if err := from.Fill("content", func(p *Element) { p.AddString("stale") }); err == nil {
+				t.Error("placeholder can still be filled through the generator it left")
+			}
+			if err := to.Fill("content", func(p *Element) { p.AddString("filled") }); err != nil {
+				t.Fatal(err)
+			}
+			if got := to.Generate(); got != tt.want {
+				t.Errorf("Generate = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFillForgetsNestedPlaceholders(t *testing.T) {
+	g := New(nil)
+	g.Root.Placeholder("content").Div().Placeholder("sidebar")
+	if err := g.Fill("content", func(p *Element) { p.P() }); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Fill("sidebar", func(*Element) {}); err == nil {
+		t.Error("placeholder inside replaced default content can still be filled")
+	}
+}
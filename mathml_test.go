@@ -0,0 +1,68 @@
+package htmlsimple
+
+import "testing"
+
+func TestMathML(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  RenderOptions
+		build func(root *Element)
+		want  string
+		warns bool
+	}{
+		{
+			name: "fraction",
+			build: func(root *Element) {
+				frac := root.Math().Attr("display", "block").Mfrac()
+				frac.Mn().AddString("1")
+				frac.Mn().AddString("2")
+			},
+			want: `<math display="block"><mfrac><mn>1</mn><mn>2</mn></mfrac></math>`,
+		},
+		{
+			name: "annotation is escaped",
+			build: func(root *Element) {
+				sem := root.Math().Semantics()
+				sem.Mi().AddString("x")
+				sem.AddAnnotation("application/x-tex", `x < \frac{1}{2}`)
+			},
+			want: `<math><semantics><mi>x</mi><annotation encoding="application/x-tex">x &lt; \frac{1}{2}</annotation></semantics></math>`,
+		},
+		{
+			name: "math attributes",
+			build: func(root *Element) {
+				root.Math().Mo().Attr("stretchy", "false").Attr("lspace", "0").AddString("(")
+			},
+			want: `<math><mo lspace="0" stretchy="false">(</mo></math>`,
+		},
+		{
+			name: "html attributes are not allowed",
+			build: func(root *Element) {
+				root.Math().Mi().Attr("onclick", "x")
+			},
+			want:  `<math><mi data-onclick="x" /></math>`,
+			warns: true,
+		},
+		{
+			name: "xhtml declares the namespace",
+			opts: RenderOptions{XHTML: true},
+			build: func(root *Element) {
+				root.Math().Mspace().Attr("width", "1em")
+			},
+			want: `<math xmlns="http://www.w3.org/1998/Math/MathML"><mspace width="1em" /></math>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(nil)
+			tt.build(g.Root)
+			if got := g.GenerateWith(tt.opts); got != tt.want {
+				t.Errorf("GenerateWith = %q, want %q", got, tt.want)
+			}
+			if warns := len(g.Diagnostics()) > 0; warns != tt.warns {
+				t.Errorf("diagnostics = %v, want warning %v", g.Diagnostics(), tt.warns)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package htmlsimple
+
+import (
+	"slices"
+	"strings"
+)
+
+// ClassMerger resolves conflicts between the classes of an element, such as
+// "p-2 p-4" in a utility CSS framework. MergeClasses receives the classes
+// in the order they were added and returns the ones to keep.
+type ClassMerger interface {
+	MergeClasses(classes []string) []string
+}
+
+// ClassMergerFunc adapts a function to a ClassMerger.
+type ClassMergerFunc func(classes []string) []string
+
+// MergeClasses calls f.
+func (f ClassMergerFunc) MergeClasses(classes []string) []string {
+	return f(classes)
+}
+
+// SetClassMerger sets the ClassMerger applied whenever classes are added
+// to an element. With a merger set, adding a class the element already has
+// moves it to the end, so it counts as the most recent. Pass nil to only
+// de-duplicate classes, which is the default.
+//
+// Example:
+//
+//	g := htmlsimple.New(nil).SetClassMerger(htmlsimple.TailwindMerger())
+//	g.Root.Button().AddClass("px-2 py-1 bg-gray-100").AddClass("p-4 bg-blue-500")
+//	// Results in: class="p-4 bg-blue-500"
+func (g *Generator) SetClassMerger(m ClassMerger) *Generator {
+	g.classMerger = m
+	return g
+}
+
+// UtilityMerger is a ClassMerger for utility class frameworks in which a
+// class is an optional list of variants, an optional important marker and
+// a utility, as in "md:hover:!p-4". Of the classes that share variants,
+// importance and utility group the last one wins. Classes without a group
+// are always kept.
+//
+// TailwindMerger returns a UtilityMerger with the Tailwind CSS groups; other
+// frameworks can supply their own Group and Conflicts.
+type UtilityMerger struct {
+	// Group returns the group of a utility, without variants or important
+	// marker, or "" if it does not belong to one. Classes of the same group
+	// set the same CSS properties.
+	Group func(utility string) string
+	// Conflicts lists, per group, the other groups a class of that group
+	// overrides, such as padding overriding horizontal padding.
+	Conflicts map[string][]string
+}
+
+// MergeClasses keeps the last class of each group. It walks the classes
+// backwards so a later class also removes earlier ones it overrides.
+func (m *UtilityMerger) MergeClasses(classes []string) []string {
+	seen := make(map[string]bool)
+	kept := make([]string, 0, len(classes))
+	for i := len(classes) - 1; i >= 0; i-- {
+		class := classes[i]
+		variants, important, utility := splitUtility(class)
+		group := m.Group(utility)
+		if group == "" {
+			kept = append(kept, class)
+			continue
+		}
+
+		scope := variants + important + ":"
+		if seen[scope+group] {
+			continue
+		}
+		seen[scope+group] = true
+		for _, other := range m.Conflicts[group] {
+			seen[scope+other] = true
+		}
+		kept = append(kept, class)
+	}
+	slices.Reverse(kept)
+	return kept
+}
+
+// splitUtility splits class into its variants, sorted so their order does
+// not matter, its important marker and the utility itself. Colons inside
+// brackets, as in "[&:hover]:p-2" or "bg-[url(a:b)]", do not split.
+func splitUtility(class string) (variants, important, utility string) {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(class); i++ {
+		switch class[i] {
+		case '[', '(':
+			depth++
+		case ']', ')':
+			depth--
+		case ':':
+			if depth == 0 {
+				parts = append(parts, class[start:i])
+				start = i + 1
+			}
+		}
+	}
+	utility = class[start:]
+
+	// Tailwind 3 marks important with a leading "!", Tailwind 4 with a
+	// trailing one.
+	if strings.HasPrefix(utility, "!") {
+		important, utility = "!", utility[1:]
+	} else if strings.HasSuffix(utility, "!") {
+		important, utility = "!", utility[:len(utility)-1]
+	}
+
+	slices.Sort(parts)
+	return strings.Join(parts, ":"), important, utility
+}
@@ -0,0 +1,114 @@
+package htmlsimple
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSplitUtility(t *testing.T) {
+	tests := []struct {
+		class                        string
+		variants, important, utility string
+	}{
+		{"p-4", "", "", "p-4"},
+		{"hover:p-4", "hover", "", "p-4"},
+		{"md:hover:p-4", "hover:md", "", "p-4"},
+		{"hover:md:p-4", "hover:md", "", "p-4"},
+		{"!p-4", "", "!", "p-4"},
+		{"md:p-4!", "md", "!", "p-4"},
+		{"[&:hover]:p-2", "[&:hover]", "", "p-2"},
+		{"bg-[url(a:b)]", "", "", "bg-[url(a:b)]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.class, func(t *testing.T) {
+			variants, important, utility := splitUtility(tt.class)
+			if variants != tt.variants || important != tt.important || utility != tt.utility {
+				t.Errorf("splitUtility(%q) = %q, %q, %q, want %q, %q, %q",
+					tt.class, variants, important, utility, tt.variants, tt.important, tt.utility)
+			}
+		})
+	}
+}
+
+func TestUtilityMerger(t *testing.T) {
+	// A framework where a utility's group is the part before its last dash.
+	m := &UtilityMerger{
+		Group: func(utility string) string {
+			if i := strings.LastIndex(utility, "-"); i > 0 {
+				return utility[:i]
+			}
+			return ""
+		},
+		Conflicts: map[string][]string{"pad": {"pad-x"}},
+	}
+
+	tests := []struct {
+		name    string
+		classes []string
+		want    []string
+	}{
+		{"last wins", []string{"pad-1", "color-red", "pad-2"}, []string{"color-red", "pad-2"}},
+		{"no group kept", []string{"card", "card", "pad-1"}, []string{"card", "card", "pad-1"}},
+		{"shorthand overrides", []string{"pad-x-1", "pad-2"}, []string{"pad-2"}},
+		{"longhand after shorthand kept", []string{"pad-2", "pad-x-1"}, []string{"pad-2", "pad-x-1"}},
+		{"variants scoped", []string{"pad-1", "md:pad-2", "pad-3"}, []string{"md:pad-2", "pad-3"}},
+		{"important scoped", []string{"!pad-1", "pad-2"}, []string{"!pad-1", "pad-2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.MergeClasses(tt.classes); !slices.Equal(got, tt.want) {
+				t.Errorf("MergeClasses(%q) = %q, want %q", tt.classes, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetClassMerger(t *testing.T) {
+	keepLast := ClassMergerFunc(func(classes []string) []string {
+		return classes[len(classes)-1:]
+	})
+
+	tests := []struct {
+		name   string
+		merger ClassMerger
+		build  func(div *Element)
+		want   string
+	}{
+		{
+			name:  "none",
+			build: func(div *Element) { div.AddClass("a", "b").AddClass("a") },
+			want:  `<div class="a b"></div>`,
+		},
+		{
+			name:   "func",
+			merger: keepLast,
+			build:  func(div *Element) { div.AddClass("a", "b").AddClass("c") },
+			want:   `<div class="c"></div>`,
+		},
+		{
+			name:   "added again moves to end",
+			merger: ClassMergerFunc(func(classes []string) []string { return classes }),
+			build:  func(div *Element) { div.AddClass("a", "b").AddClass("a") },
+			want:   `<div class="b a"></div>`,
+		},
+		{
+			name:   "tailwind",
+			merger: TailwindMerger(),
+			build:  func(div *Element) { div.AddClass("px-2 py-1 bg-gray-100").AddClass("p-4 bg-blue-500") },
+			want:   `<div class="p-4 bg-blue-500"></div>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(nil).SetClassMerger(tt.merger)
+			tt.build(g.Root.Div())
+			if got := g.Generate(); got != tt.want {
+				t.Errorf("Generate = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
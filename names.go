@@ -0,0 +1,84 @@
+package htmlsimple
+
+import "fmt"
+
+// InvalidNameMode controls what happens when an element or attribute name
+// is not a valid HTML name, for example NormalTag("div onload=alert(1)").
+type InvalidNameMode int
+
+const (
+	// InvalidNameError drops the element or attribute and records a
+	// diagnostic with SeverityError, so it is returned by Generator.Err.
+	// This is the default.
+	InvalidNameError InvalidNameMode = iota
+	// InvalidNameDrop silently drops the element or attribute.
+	InvalidNameDrop
+	// InvalidNamePanic panics.
+	InvalidNamePanic
+)
+
+// SetInvalidNameMode sets how invalid element and attribute names are
+// handled.
+func (g *Generator) SetInvalidNameMode(mode InvalidNameMode) *Generator {
+	g.invalidNameMode = mode
+	return g
+}
+
+// checkName reports whether name, used on or below e, is valid according
+// to valid. Invalid names are handled according to the generator's
+// InvalidNameMode.
+func (g *Generator) checkName(e *Element, kind, name string, valid func(string) bool) bool {
+	if valid(name) {
+		return true
+	}
+
+	switch g.invalidNameMode {
+	case InvalidNamePanic:
+		panic(fmt.Errorf("htmlsimple: invalid %s name %q", kind, name))
+	case InvalidNameError:
+		g.report(e, SeverityError, "invalid %s name %q dropped", kind, name)
+	}
+	return false
+}
+
+// validElementName reports whether name can be written as a tag name
+// without changing the meaning of the markup. Names start with an ASCII
+// letter followed by letters, digits, '-', '.', '_' or the non-ASCII
+// characters allowed in custom element names.
+func validElementName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, c := range name {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		case i == 0:
+			return false
+		case c >= '0' && c <= '9', c == '-', c == '.', c == '_':
+		case c >= 0x80 && isPCENChar(c):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// validAttributeName reports whether name is a valid attribute name: no
+// whitespace, control characters, quotes, '<', '>', '/', '=' or
+// noncharacters.
+func validAttributeName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		switch {
+		case c <= 0x20, c >= 0x7F && c <= 0x9F:
+			return false
+		case c == '"', c == '\'', c == '<', c == '>', c == '/', c == '=', c == '`':
+			return false
+		case c >= 0xFDD0 && c <= 0xFDEF, c&0xFFFE == 0xFFFE:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,101 @@
+package htmlsimple
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidElementName(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"div", true},
+		{"h1", true},
+		{"foreignObject", true},
+		{"my-widget", true},
+		{"x-ä", true},
+		{"", false},
+		{"1div", false},
+		{"-div", false},
+		{"div onload=alert(1)", false},
+		{"div>", false},
+		{"di/v", false},
+		{"a\x00", false},
+	}
+	for _, tt := range tests {
+		if got := validElementName(tt.name); got != tt.want {
+			t.Errorf("validElementName(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestValidAttributeName(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"href", true},
+		{"data-x", true},
+		{"xlink:href", true},
+		{"@click", true},
+		{"viewBox", true},
+		{"", false},
+		{"on click", false},
+		{"a=b", false},
+		{`a"`, false},
+		{"a'", false},
+		{"a>", false},
+		{"a/", false},
+		{"a`", false},
+		{"a\u0085", false},
+		{"a﷐", false},
+		{"a￿", false},
+	}
+	for _, tt := range tests {
+		if got := validAttributeName(tt.name); got != tt.want {
+			t.Errorf("validAttributeName(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestInvalidNameMode(t *testing.T) {
+	build := func(g *Generator) {
+		div := g.Root.Div()
+		div.Add(NormalTag("p onclick=alert(1)")).AddString("dropped")
+		div.Attr("x y", "1").Attr("title", "kept")
+	}
+
+	tests := []struct {
+		mode      InvalidNameMode
+		wantErr   bool
+		wantPanic bool
+	}{
+		{InvalidNameError, true, false},
+		{InvalidNameDrop, false, false},
+		{InvalidNamePanic, false, true},
+	}
+
+	for _, tt := range tests {
+		g := New(nil).SetInvalidNameMode(tt.mode)
+		func() {
+			defer func() {
+				if panicked := recover() != nil; panicked != tt.wantPanic {
+					t.Errorf("mode %d: panicked = %v, want %v", tt.mode, panicked, tt.wantPanic)
+				}
+			}()
+			build(g)
+		}()
+		if tt.wantPanic {
+			continue
+		}
+
+		if got, want := g.Generate(), `<div title="kept"></div>`; got != want {
+			t.Errorf("mode %d: Generate = %q, want %q", tt.mode, got, want)
+		}
+		var d Diagnostic
+		if gotErr := errors.As(g.Err(), &d); gotErr != tt.wantErr {
+			t.Errorf("mode %d: Err = %v, want error %v", tt.mode, g.Err(), tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,5 @@
+//go:build !race
+
+package htmlsimple
+
+const raceEnabled = false
@@ -0,0 +1,220 @@
+package htmlsimple
+
+import (
+	"html"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// Policy is the set of attributes a Generator allows, per namespace, and
+// the sanitizer applied to each of their values. A Policy cannot be changed
+// after it is built, so it is safe to share between generators and
+// goroutines.
+type Policy struct {
+	html map[string]attributeConfig
+	svg  map[string]attributeConfig
+	math map[string]attributeConfig
+	// elements holds the HTML attributes that are only allowed on some
+	// elements, keyed by tag name.
+	elements map[string]map[string]attributeConfig
+}
+
+var (
+	defaultPolicy     *Policy
+	defaultPolicyOnce sync.Once
+)
+
+// DefaultPolicy returns the Policy used by New without custom attributes.
+// It is built once and shared.
+func DefaultPolicy() *Policy {
+	defaultPolicyOnce.Do(func() {
+		defaultPolicy = NewPolicy(nil)
+	})
+	return defaultPolicy
+}
+
+// NewPolicy builds a Policy from the default attributes plus
+// allowedAttributesCustom.
+//
+// URL attributes such as href, src and action are always allowed, with
+// values that are not safe URLs replaced by "#". A custom attribute that
+// has the name of a default one is ignored, so it cannot switch that
+// sanitizing off.
+func NewPolicy(allowedAttributesCustom []Attribute) *Policy {
+	p := &Policy{
+		html:     make(map[string]attributeConfig),
+		svg:      make(map[string]attributeConfig),
+		math:     make(map[string]attributeConfig),
+		elements: make(map[string]map[string]attributeConfig),
+	}
+
+	defaultAllowed := []string{
+		"accept", "accept-charset", "accesskey", "allow", "alt", "as", "async",
+		"autocapitalize", "autocomplete", "autoplay", "background", "bgcolor", "border",
+		"capture", "charset", "checked", "cite", "class", "color", "cols", "colspan",
+		"content", "contenteditable", "controls", "coords", "crossorigin", "data", "data-*",
+		"datetime", "decoding", "default", "defer", "dir", "dirname", "disabled", "download",
+		"draggable", "enctype", "enterkeyhint", "for", "form", "formenctype",
+		"formmethod", "formnovalidate", "formtarget", "headers", "height", "hidden", "high",
+		"hreflang", "http-equiv", "id", "integrity", "inputmode", "ismap", "itemprop",
+		"kind", "label", "lang", "loading", "list", "loop", "low", "max", "maxlength",
+		"minlength", "media", "method", "min", "multiple", "muted", "name", "novalidate",
+		"open", "optimum", "pattern", "placeholder", "playsinline",
+		"preload", "readonly", "referrerpolicy", "rel", "required", "reversed", "role",
+		"rows", "rowspan", "sandbox", "scope", "selected", "shadowrootclonable",
+		"shadowrootdelegatesfocus", "shadowrootmode", "shape", "size", "sizes",
+		"slot", "span", "spellcheck", "srcdoc", "srclang", "start", "step",
+		"style", "tabindex", "target", "title", "translate", "type", "usemap", "value",
+		"width", "wrap",
+	}
+
+	defaultAllowedUrl := []string{
+		"href", "src", "action", "formaction", "srcset", "ping", "poster",
+	}
+
+	defaultAllowedHtmx := []string{
+		"hx-get", "hx-post", "hx-put", "hx-patch", "hx-delete",
+	}
+	defaultAllowedUrl = append(defaultAllowedUrl, defaultAllowedHtmx...)
+
+	for _, attr := range defaultAllowed {
+		p.html[attr] = attributeConfig{allowed: true, sanitizeFunc: html.EscapeString}
+	}
+
+	for _, attr := range defaultAllowedUrl {
+		p.html[attr] = attributeConfig{allowed: true, sanitizeFunc: sanitizeUrl}
+	}
+
+	// Custom attributes never replace a default, so they cannot switch off
+	// URL sanitizing for href and friends.
+	for _, attribute := range allowedAttributesCustom {
+		if _, exists := p.html[attribute.Name]; exists {
+			continue
+		}
+		p.html[attribute.Name] = attributeConfig{allowed: true, sanitizeFunc: html.EscapeString}
+	}
+
+	// Attributes from the element table that are not allowed everywhere,
+	// such as fetchpriority or popovertarget, are allowed on the elements
+	// that define them.
+	for tag, attrs := range elementAttributes {
+		for _, attr := range attrs {
+			if _, exists := p.html[attr]; exists {
+				continue
+			}
+			if p.elements[tag] == nil {
+				p.elements[tag] = make(map[string]attributeConfig)
+			}
+			p.elements[tag][attr] = attributeConfig{allowed: true, sanitizeFunc: html.EscapeString}
+		}
+	}
+
+	// SVG attribute names are case-sensitive (viewBox, preserveAspectRatio).
+	// Event handlers are deliberately absent so they end up as data-*.
+	defaultAllowedSvg := []string{
+		"alignment-baseline", "baseline-shift", "class", "clip-path", "clip-rule",
+		"clipPathUnits", "color", "color-interpolation", "color-interpolation-filters",
+		"cursor", "cx", "cy", "d", "direction", "display", "dominant-baseline", "dx",
+		"dy", "fill", "fill-opacity", "fill-rule", "filter", "filterUnits",
+		"flood-color", "flood-opacity", "focusable", "font-family", "font-size",
+		"font-size-adjust", "font-stretch", "font-style", "font-variant",
+		"font-weight", "fr", "fx", "fy", "gradientTransform", "gradientUnits",
+		"height", "id", "image-rendering", "in", "in2", "lang", "lengthAdjust",
+		"letter-spacing", "lighting-color", "marker-end", "marker-mid",
+		"marker-start", "markerHeight", "markerUnits", "markerWidth", "mask",
+		"maskContentUnits", "maskUnits", "mode", "offset", "opacity", "operator",
+		"orient", "overflow", "paint-order", "pathLength", "patternContentUnits",
+		"patternTransform", "patternUnits", "pointer-events", "points",
+		"preserveAspectRatio", "primitiveUnits", "r", "refX", "refY", "result",
+		"role", "rotate", "rx", "ry", "shape-rendering", "spreadMethod", "startOffset",
+		"stdDeviation", "stop-color", "stop-opacity", "stroke", "stroke-dasharray",
+		"stroke-dashoffset", "stroke-linecap", "stroke-linejoin",
+		"stroke-miterlimit", "stroke-opacity", "stroke-width", "style",
+		"systemLanguage", "tabindex", "text-anchor", "text-decoration",
+		"text-rendering", "textLength", "transform", "transform-origin", "type",
+		"unicode-bidi", "values", "vector-effect", "version", "viewBox",
+		"visibility", "width", "word-spacing", "writing-mode", "x", "x1", "x2",
+		"y", "y1", "y2",
+	}
+
+	defaultAllowedSvgUrl := []string{
+		"href", "xlink:href",
+	}
+
+	for _, attr := range defaultAllowedSvg {
+		p.svg[attr] = attributeConfig{allowed: true, sanitizeFunc: html.EscapeString}
+	}
+	for _, attr := range defaultAllowedSvgUrl {
+		p.svg[attr] = attributeConfig{allowed: true, sanitizeFunc: sanitizeSvgUrl}
+	}
+
+	// WAI-ARIA states and properties apply to HTML and SVG elements alike.
+	for attr := range ariaAttributes {
+		p.html[attr] = attributeConfig{allowed: true, sanitizeFunc: html.EscapeString}
+		p.svg[attr] = attributeConfig{allowed: true, sanitizeFunc: html.EscapeString}
+	}
+
+	// MathML Core global and element specific attributes.
+	defaultAllowedMath := []string{
+		"accent", "accentunder", "actiontype", "alttext", "arg", "autofocus",
+		"class", "columnspan", "depth", "dir", "display", "displaystyle",
+		"encoding", "fence", "form", "height", "id", "intent", "largeop",
+		"linethickness", "lquote", "lspace", "mathbackground", "mathcolor",
+		"mathsize", "mathvariant", "maxsize", "minsize", "movablelimits",
+		"rowspan", "rquote", "rspace", "scriptlevel", "selection", "separator",
+		"stretchy", "style", "symmetric", "tabindex", "voffset", "width",
+	}
+
+	for _, attr := range defaultAllowedMath {
+		p.math[attr] = attributeConfig{allowed: true, sanitizeFunc: html.EscapeString}
+	}
+
+	return p
+}
+
+// sanitizeUrl only lets through absolute URLs with a non-script scheme and
+// root-relative paths. Everything else becomes "#".
+func sanitizeUrl(s string) string {
+	u, err := url.Parse(s)
+	if err != nil {
+		return "#"
+	}
+	if u.Scheme == "javascript" {
+		return "#"
+	}
+	if u.Scheme == "" && !strings.HasPrefix(u.Path, "/") {
+		return "#"
+	}
+	return html.EscapeString(u.String())
+}
+
+// sanitizeSvgUrl works like sanitizeUrl but also accepts same-document
+// references such as "#icon", which SVG uses to point at gradients, masks
+// and symbols.
+func sanitizeSvgUrl(s string) string {
+	if strings.HasPrefix(s, "#") {
+		return html.EscapeString(s)
+	}
+	return sanitizeUrl(s)
+}
+
+// attributePolicy returns the allowed attributes for elements with tag.
+func (g *Generator) attributePolicy(tag Tag) map[string]attributeConfig {
+	switch tag.(type) {
+	case SvgTag:
+		return g.policy.svg
+	case MathTag:
+		return g.policy.math
+	}
+	return g.policy.html
+}
+
+// elementPolicy returns the attributes allowed only on elements with tag.
+func (g *Generator) elementPolicy(tag Tag) map[string]attributeConfig {
+	switch tag.(type) {
+	case NormalTag, VoidTag:
+		return g.policy.elements[tag.name()]
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package htmlsimple
+
+import "testing"
+
+func TestElementSpecificAttributes(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(root *Element)
+		want  string
+	}{
+		{
+			name:  "fetchpriority on img",
+			build: func(root *Element) { root.Img().Attr("fetchpriority", "high") },
+			want:  `<img fetchpriority="high" />`,
+		},
+		{
+			name:  "fetchpriority on link",
+			build: func(root *Element) { root.Link().Attr("fetchpriority", "low") },
+			want:  `<link fetchpriority="low" />`,
+		},
+		{
+			name:  "blocking on script",
+			build: func(root *Element) { root.Script().Attr("blocking", "render") },
+			want:  `<script blocking="render"></script>`,
+		},
+		{
+			name:  "popovertarget on button",
+			build: func(root *Element) { root.Button().Attr("popovertarget", "menu") },
+			want:  `<button popovertarget="menu"></button>`,
+		},
+		{
+			name:  "allowfullscreen on iframe",
+			build: func(root *Element) { root.Iframe().Attr("allowfullscreen", "") },
+			want:  `<iframe allowfullscreen=""></iframe>`,
+		},
+		{
+			name:  "fetchpriority elsewhere",
+			build: func(root *Element) { root.Div().Attr("fetchpriority", "high") },
+			want:  `<div data-fetchpriority="high"></div>`,
+		},
+		{
+			name:  "popovertarget elsewhere",
+			build: func(root *Element) { root.Span().Attr("popovertarget", "menu") },
+			want:  `<span data-popovertarget="menu"></span>`,
+		},
+		{
+			name:  "not on svg elements of the same name",
+			build: func(root *Element) { root.Svg().AddSvg(SvgTag("script")).Attr("blocking", "render") },
+			want:  `<svg><script data-blocking="render" /></svg>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(nil)
+			tt.build(g.Root)
+			if got := g.Generate(); got != tt.want {
+				t.Errorf("Generate = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestElementAttributesCoverTable(t *testing.T) {
+	g := New(nil)
+	for tag, attrs := range elementAttributes {
+		e := &Element{Tag: NormalTag(tag), generator: g}
+		for _, attr := range attrs {
+			if _, ok := e.lookupAttribute(attr); !ok {
+				t.Errorf("<%s %s> is listed in the element table but not allowed", tag, attr)
+			}
+		}
+	}
+}
+
+// URL attributes used to be allowed only when custom attributes were
+// passed, and a custom attribute replaced the default of the same name,
+// turning off its URL sanitizing.
+func TestPolicyURLAttributes(t *testing.T) {
+	tests := []struct {
+		name   string
+		custom []Attribute
+		build  func(root *Element)
+		want   string
+	}{
+		{
+			name:  "href without custom attributes",
+			build: func(root *Element) { root.A().Attr("href", "/home") },
+			want:  `<a href="/home"></a>`,
+		},
+		{
+			name:  "action without custom attributes",
+			build: func(root *Element) { root.Form().Attr("action", "/save") },
+			want:  `<form action="/save"></form>`,
+		},
+		{
+			name:  "unsafe href without custom attributes",
+			build: func(root *Element) { root.A().Attr("href", "javascript:alert(1)") },
+			want:  `<a href="#"></a>`,
+		},
+		{
+			name:   "custom attribute cannot unsanitize href",
+			custom: []Attribute{{Name: "href"}},
+			build:  func(root *Element) { root.A().Attr("href", "javascript:alert(1)") },
+			want:   `<a href="#"></a>`,
+		},
+		{
+			name:   "custom attribute cannot unsanitize src",
+			custom: []Attribute{{Name: "hx-target"}, {Name: "src"}},
+			build:  func(root *Element) { root.Img().Attr("src", "javascript:alert(1)") },
+			want:   `<img src="#" />`,
+		},
+		{
+			name:   "custom attribute is allowed",
+			custom: []Attribute{{Name: "hx-target"}},
+			build:  func(root *Element) { root.Div().Attr("hx-target", "#main") },
+			want:   `<div hx-target="#main"></div>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(tt.custom)
+			tt.build(g.Root)
+			if got := g.Generate(); got != tt.want {
+				t.Errorf("Generate = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package htmlsimple
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// maxPooledBuffer is the capacity above which a render buffer is left to
+// the garbage collector instead of being pooled, so one huge page does not
+// pin its memory.
+const maxPooledBuffer = 1 << 20
+
+var (
+	elementPool = sync.Pool{New: func() any { return new(Element) }}
+	bufferPool  = sync.Pool{New: func() any { return new(bytes.Buffer) }}
+)
+
+// newElement returns an element for tag below parent, reusing a released
+// one when available. Attributes and Children are only allocated once they
+// are needed.
+func newElement(tag Tag, parent *Element) *Element {
+	e := elementPool.Get().(*Element)
+	e.Tag = tag
+	e.Parent = parent
+	e.generator = parent.generator
+	return e
+}
+
+// Release returns every element of the generator's tree to a pool, so the
+// next trees built in the process allocate less, and leaves the generator
+// with an empty tree and no diagnostics. Elements of the released tree must
+// not be used afterwards, including any still held in variables.
+//
+// Example:
+//
+//	g := htmlsimple.New(nil)
+//	defer g.Release()
+//	buildPage(g.Root)
+//	g.Root.RenderTo(w, htmlsimple.RenderOptions{})
+func (g *Generator) Release() {
+	g.Root.release()
+	g.Root = &Element{Tag: NormalTag(""), generator: g}
+
+	g.mu.Lock()
+	g.diagnostics = nil
+	g.placeholders = nil
+	g.ids = nil
+	g.idCounters = nil
+	g.mu.Unlock()
+}
+
+// release puts e and its descendants back into the pool. The maps and
+// slices are kept, emptied, so reused elements do not allocate them again.
+func (e *Element) release() {
+	for _, c := range e.Children {
+		if el, ok := c.(*Element); ok {
+			el.release()
+		}
+	}
+	clear(e.Attributes)
+	clear(e.Children)
+	*e = Element{Attributes: e.Attributes, Children: e.Children[:0]}
+	elementPool.Put(e)
+}
+
+// RenderTo writes the HTML for e and its descendants, serialized according
+// to opts, to w. Unlike Render it does not build a string, and the buffer
+// it renders into is reused between calls.
+func (e *Element) RenderTo(w io.Writer, opts RenderOptions) error {
+	buf := getBuffer()
+	defer putBuffer(buf)
+	e.render(buf, opts)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+func getBuffer() *bytes.Buffer {
+	return bufferPool.Get().(*bytes.Buffer)
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBuffer {
+		return
+	}
+	buf.Reset()
+	bufferPool.Put(buf)
+}
@@ -0,0 +1,84 @@
+package htmlsimple
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"testing"
+)
+
+func TestRenderToMatchesRender(t *testing.T) {
+	g := New(nil)
+	bigPage(g, 2)
+	for _, opts := range []RenderOptions{{}, {Minify: true}, {Pretty: true}, {XHTML: true}, {Parallel: 4}} {
+		var buf bytes.Buffer
+		if err := g.Root.RenderTo(&buf, opts); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := buf.String(), g.Root.Render(opts); got != want {
+			t.Errorf("%+v: RenderTo differs from Render", opts)
+		}
+	}
+}
+
+func TestRelease(t *testing.T) {
+	g := New(nil)
+	main := g.Root.Div().Attr("id", "main").Attr("onclick", "x")
+	main.Placeholder("content").P().AddString("default")
+	g.UniqueID("item")
+	g.Release()
+
+	if got := g.Generate(); got != "" {
+		t.Errorf("Generate after Release = %q, want empty", got)
+	}
+	if d := g.Diagnostics(); len(d) > 0 {
+		t.Errorf("Diagnostics after Release = %v", d)
+	}
+	if err := g.Fill("content", func(*Element) {}); err == nil {
+		t.Error("placeholder survived Release")
+	}
+	if id := g.UniqueID("item"); id != "item-1" {
+		t.Errorf("UniqueID after Release = %q, want item-1", id)
+	}
+
+	// Released elements are reused without carrying anything over.
+	for i := 0; i < 100; i++ {
+		g.Root.Span().AddString(strconv.Itoa(i))
+	}
+	g.Root.Div().Attr("id", "main")
+	if d := g.Diagnostics(); len(d) > 0 {
+		t.Errorf("Diagnostics of rebuilt tree = %v", d)
+	}
+	for _, c := range g.Root.Children {
+		e := c.(*Element)
+		if len(e.Children) > 0 || (e.Tag.name() == "span" && len(e.Attributes) > 0) {
+			t.Fatalf("reused element carries old state: %+v", e)
+		}
+	}
+}
+
+func TestLazyAllocation(t *testing.T) {
+	g := New(nil)
+	e := g.Root.Div()
+	if e.Attributes != nil || e.Children != nil {
+		t.Errorf("new element allocated Attributes %v or Children %v", e.Attributes, e.Children)
+	}
+}
+
+func TestRenderToAllocations(t *testing.T) {
+	if raceEnabled {
+		t.Skip("sync.Pool is not reliable under the race detector")
+	}
+	g := New(nil)
+	bigPage(g, 2)
+
+	for _, opts := range []RenderOptions{{}, {Minify: true}} {
+		g.Root.RenderTo(io.Discard, opts)
+		allocs := testing.AllocsPerRun(20, func() {
+			g.Root.RenderTo(io.Discard, opts)
+		})
+		if allocs > 2 {
+			t.Errorf("%+v: RenderTo made %v allocations, want at most 2", opts, allocs)
+		}
+	}
+}
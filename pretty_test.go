@@ -0,0 +1,100 @@
+package htmlsimple
+
+import "testing"
+
+func TestPretty(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  RenderOptions
+		build func(root *Element)
+		want  string
+	}{
+		{
+			name: "blocks on their own lines",
+			opts: RenderOptions{Pretty: true},
+			build: func(root *Element) {
+				html := root.Html()
+				html.Head().Title().AddString("T")
+				body := html.Body()
+				body.H1().AddString("Hello")
+				body.Div().Div()
+				body.Img().Attr("src", "/x.png")
+			},
+			want: `<html>
+  <head>
+    <title>T</title>
+  </head>
+  <body>
+    <h1>Hello</h1>
+    <div>
+      <div></div>
+    </div>
+    <img src="/x.png" />
+  </body>
+</html>
+`,
+		},
+		{
+			name: "custom indent",
+			opts: RenderOptions{Pretty: true, Indent: "\t"},
+			build: func(root *Element) {
+				root.Ul().Li().AddString("one")
+			},
+			want: "<ul>\n\t<li>one</li>\n</ul>\n",
+		},
+		{
+			name: "inline content stays on one line",
+			opts: RenderOptions{Pretty: true},
+			build: func(root *Element) {
+				p := root.Div().P().AddString("Some text")
+				p.Em().AddString("inline")
+			},
+			want: "<div>\n  <p>Some text<em>inline</em></p>\n</div>\n",
+		},
+		{
+			name: "wraps at line width",
+			opts: RenderOptions{Pretty: true, LineWidth: 15},
+			build: func(root *Element) {
+				root.P().AddString("aaaa bbbb cccc dddd eeee ffff gggg")
+			},
+			want: "<p>\n  aaaa bbbb\n  cccc dddd\n  eeee ffff\n  gggg\n</p>\n",
+		},
+		{
+			name: "wraps inside inline elements at whitespace",
+			opts: RenderOptions{Pretty: true, LineWidth: 10},
+			build: func(root *Element) {
+				p := root.P().AddString("aaaa")
+				p.Strong().AddString("bb cc")
+			},
+			want: "<p>\n  aaaa<strong>bb\n  cc</strong>\n</p>\n",
+		},
+		{
+			name: "preformatted content is verbatim",
+			opts: RenderOptions{Pretty: true},
+			build: func(root *Element) {
+				div := root.Div()
+				div.Pre().AddString("  a\n b")
+				div.Textarea().AddString(" x ")
+			},
+			want: "<div>\n  <pre>  a\n b</pre>\n  <textarea> x </textarea>\n</div>\n",
+		},
+		{
+			name: "minify wins over pretty",
+			opts: RenderOptions{Pretty: true, Minify: true},
+			build: func(root *Element) {
+				root.Ul().Li().AddString("one")
+			},
+			want: "<ul><li>one</ul>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(nil)
+			tt.build(g.Root)
+			if got := g.GenerateWith(tt.opts); got != tt.want {
+				t.Errorf("GenerateWith = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+//go:build race
+
+package htmlsimple
+
+// raceEnabled is set when the race detector is on. It makes sync.Pool drop
+// items at random, so allocation counts are not reliable.
+const raceEnabled = true
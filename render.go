@@ -0,0 +1,661 @@
+package htmlsimple
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+)
+
+// RenderOptions controls how a tree is serialized. The zero value produces
+// compact output on a single line.
+type RenderOptions struct {
+	// Pretty puts block-level elements on their own lines, indented by
+	// nesting depth. Inline content is kept together and wrapped at
+	// LineWidth. Whitespace inside pre, textarea, script and style is
+	// never altered.
+	Pretty bool
+	// Indent is the string written once per nesting level when Pretty is
+	// set. Defaults to two spaces.
+	Indent string
+	// LineWidth is the column at which inline content is wrapped when
+	// Pretty is set. Defaults to 80.
+	LineWidth int
+	// Minify produces the smallest equivalent HTML: optional end tags are
+	// omitted, attribute values are unquoted where that is safe, whitespace
+	// in text outside preformatted elements is collapsed and attributes set
+	// to their default value are dropped. Pretty is ignored when Minify is
+	// set.
+	Minify bool
+	// XHTML produces well-formed XML: the XHTML namespace is declared on the
+	// outermost element, empty elements are self-closed, text and attributes
+	// use XML escapes and boolean attributes are written as attr="attr".
+	// Minify is ignored when XHTML is set.
+	XHTML bool
+	// Parallel is the number of goroutines used to render large sibling
+	// subtrees concurrently. The output is identical to a sequential render.
+	// Values below 2 render sequentially, as does Pretty output.
+	Parallel int
+}
+
+const (
+	xhtmlNamespace = "http://www.w3.org/1999/xhtml"
+	svgNamespace   = "http://www.w3.org/2000/svg"
+	mathNamespace  = "http://www.w3.org/1998/Math/MathML"
+	xlinkNamespace = "http://www.w3.org/1999/xlink"
+)
+
+// Elements whose content is whitespace sensitive. They are always written
+// verbatim, even in pretty mode.
+var preformattedTags = map[string]bool{
+	"pre":       true,
+	"textarea":  true,
+	"script":    true,
+	"style":     true,
+	"xmp":       true,
+	"plaintext": true,
+}
+
+// End tags that HTML allows to be left out, keyed by element. Each entry
+// lists the next siblings that close the element implicitly; closedByParent
+// means the end tag may also be dropped when the element is the last child.
+var optionalEndTags = map[string]struct {
+	closedBy       []string
+	closedByParent bool
+}{
+	"li":       {[]string{"li"}, true},
+	"dt":       {[]string{"dt", "dd"}, false},
+	"dd":       {[]string{"dt", "dd"}, true},
+	"rt":       {[]string{"rt", "rp"}, true},
+	"rp":       {[]string{"rt", "rp"}, true},
+	"optgroup": {[]string{"optgroup", "hr"}, true},
+	"option":   {[]string{"option", "optgroup", "hr"}, true},
+	"thead":    {[]string{"tbody", "tfoot"}, false},
+	"tbody":    {[]string{"tbody", "tfoot"}, true},
+	"tfoot":    {nil, true},
+	"tr":       {[]string{"tr"}, true},
+	"td":       {[]string{"td", "th"}, true},
+	"th":       {[]string{"td", "th"}, true},
+	"p": {[]string{
+		"address", "article", "aside", "blockquote", "details", "dialog", "div",
+		"dl", "fieldset", "figcaption", "figure", "footer", "form", "h1", "h2",
+		"h3", "h4", "h5", "h6", "header", "hgroup", "hr", "main", "menu", "nav",
+		"ol", "p", "pre", "search", "section", "table", "ul",
+	}, true},
+}
+
+// Parents in which a trailing <p> must keep its end tag.
+var pEndTagRequiredIn = map[string]bool{
+	"a": true, "audio": true, "del": true, "ins": true, "map": true,
+	"noscript": true, "video": true,
+}
+
+// Attribute values that match the browser default and can be dropped when
+// minifying, keyed by element and attribute name.
+var defaultAttributeValues = map[string]map[string]string{
+	"area":   {"shape": "rect"},
+	"button": {"type": "submit"},
+	"form":   {"method": "get", "enctype": "application/x-www-form-urlencoded"},
+	"input":  {"type": "text"},
+	"link":   {"media": "all"},
+	"script": {"type": "text/javascript", "language": "javascript"},
+	"style":  {"type": "text/css", "media": "all"},
+}
+
+// Attributes whose presence alone switches them on.
+var booleanAttributes = map[string]bool{
+	"allowfullscreen": true, "async": true, "autofocus": true, "autoplay": true,
+	"checked": true, "controls": true, "default": true, "defer": true,
+	"disabled": true, "formnovalidate": true, "hidden": true, "inert": true,
+	"ismap": true, "itemscope": true, "loop": true, "multiple": true,
+	"muted": true, "nomodule": true, "novalidate": true, "open": true,
+	"playsinline": true, "readonly": true, "required": true, "reversed": true,
+	"selected": true,
+}
+
+// Content and attribute values are stored escaped for HTML. These are the
+// only numeric references html.EscapeString produces, and XML has named
+// entities for both.
+var xmlEscaper = strings.NewReplacer("&#39;", "&apos;", "&#34;", "&quot;")
+
+type renderer struct {
+	builder *bytes.Buffer
+	opts    RenderOptions
+	// top is the element Render was called on.
+	top *Element
+	// next is the sibling following the element about to be rendered, or
+	// nil when it is the last child.
+	next elementI
+	// preformatted counts the whitespace sensitive elements currently open.
+	preformatted int
+	// workers holds a token per extra goroutine available to a parallel
+	// render. It is nil when rendering sequentially.
+	workers chan struct{}
+	// cuts collects the holes met while freezing a Static. It is nil in a
+	// normal render.
+	cuts *[]cut
+}
+
+// Render returns the sanitized HTML for e and its descendants, serialized
+// according to opts.
+func (e *Element) Render(opts RenderOptions) string {
+	buf := getBuffer()
+	defer putBuffer(buf)
+	e.render(buf, opts)
+	return buf.String()
+}
+
+// render writes e to buf according to opts.
+func (e *Element) render(buf *bytes.Buffer, opts RenderOptions) {
+	e.renderWith(buf, opts, nil)
+}
+
+// renderWith is render recording the holes it meets in cuts.
+func (e *Element) renderWith(buf *bytes.Buffer, opts RenderOptions, cuts *[]cut) {
+	if opts.Indent == "" {
+		opts.Indent = "  "
+	}
+	if opts.LineWidth <= 0 {
+		opts.LineWidth = 80
+	}
+	if opts.XHTML {
+		opts.Minify = false
+	}
+
+	r := &renderer{builder: buf, opts: opts, top: e, cuts: cuts}
+	if opts.Parallel > 1 {
+		r.workers = make(chan struct{}, opts.Parallel-1)
+	}
+	if opts.Pretty && !opts.Minify {
+		r.block(e, 0)
+	} else {
+		e.generateHtml(r)
+	}
+}
+
+func (e *Element) generateHtml(r *renderer) {
+	next := r.next
+	if e.Tag.name() == "" {
+		// Text added to a placeholder is part of its default content.
+		if e.placeholder != "" && e.Content != "" {
+			r.builder.WriteString(r.text(e.Content))
+		}
+		r.generateChildren(e)
+		return
+	}
+
+	r.openTag(e)
+	if r.selfClosing(e) {
+		return
+	}
+
+	if preformattedTags[e.Tag.name()] {
+		r.preformatted++
+		defer func() { r.preformatted-- }()
+	}
+
+	if e.Content != "" {
+		r.builder.WriteString(r.text(e.Content))
+	}
+
+	r.generateChildren(e)
+
+	if r.opts.Minify && r.canOmitEndTag(e, next) {
+		return
+	}
+	r.closeTag(e)
+}
+
+func (r *renderer) generateChildren(e *Element) {
+	if r.workers != nil && len(e.Children) > 1 {
+		r.generateChildrenParallel(e)
+		return
+	}
+	for i, child := range e.Children {
+		r.next = nil
+		if i+1 < len(e.Children) {
+			r.next = e.Children[i+1]
+		}
+		child.generateHtml(r)
+	}
+}
+
+// canOmitEndTag reports whether the end tag of e may be left out when e is
+// followed by next, following the optional tag rules of the HTML spec.
+func (r *renderer) canOmitEndTag(e *Element, next elementI) bool {
+	rule, ok := optionalEndTags[e.Tag.name()]
+	if !ok {
+		return false
+	}
+
+	if next == nil {
+		// Output may be embedded anywhere, so only rely on the parent
+		// closing e when that parent is part of this render.
+		if !rule.closedByParent || !r.rendersEndTag(e.Parent) {
+			return false
+		}
+		// Only the end tag of an HTML parent closes e; that of an SVG or
+		// MathML parent, such as </foreignObject>, does not.
+		if _, ok := e.Parent.Tag.(NormalTag); !ok {
+			return false
+		}
+		if e.Tag.name() == "p" {
+			parent := e.Parent.Tag.name()
+			return !pEndTagRequiredIn[parent] && !strings.Contains(parent, "-")
+		}
+		return true
+	}
+
+	sibling, ok := next.(*Element)
+	if !ok {
+		return false
+	}
+	for _, name := range rule.closedBy {
+		if sibling.Tag.name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// rendersEndTag reports whether the end tag of e is written by the current
+// render: e has a tag and is r.top or one of its descendants.
+func (r *renderer) rendersEndTag(e *Element) bool {
+	if e == nil || e.Tag.name() == "" {
+		return false
+	}
+	for p := e; p != nil; p = p.Parent {
+		if p == r.top {
+			return true
+		}
+	}
+	return false
+}
+
+// collapseWhitespace replaces every run of whitespace in s with a single
+// space. Text that needs no change is returned as is, without allocating.
+func collapseWhitespace(s string) string {
+	if !needsCollapse(s) {
+		return s
+	}
+
+	var builder strings.Builder
+	space := false
+	for _, c := range s {
+		switch c {
+		case ' ', '\t', '\n', '\r', '\f':
+			if !space {
+				builder.WriteByte(' ')
+			}
+			space = true
+		default:
+			builder.WriteRune(c)
+			space = false
+		}
+	}
+	return builder.String()
+}
+
+// needsCollapse reports whether s contains whitespace other than single
+// spaces.
+func needsCollapse(s string) bool {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\t', '\n', '\r', '\f':
+			return true
+		case ' ':
+			if i+1 < len(s) && s[i+1] == ' ' {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (e *Element) isVoid() bool {
+	_, isVoid := e.Tag.(VoidTag)
+	return isVoid
+}
+
+// selfClosing reports whether e is written as a single start tag with no
+// end tag.
+func (r *renderer) selfClosing(e *Element) bool {
+	if e.isVoid() {
+		return true
+	}
+	if e.Content != "" || len(e.Children) > 0 {
+		return false
+	}
+	// HTML honours the self-closing flag on foreign elements.
+	return r.opts.XHTML || namespace(e) != xhtmlNamespace
+}
+
+// text returns escaped content ready to be written in the current mode.
+func (r *renderer) text(s string) string {
+	switch {
+	case r.opts.XHTML:
+		return xmlEscaper.Replace(s)
+	case r.opts.Minify && r.preformatted == 0:
+		return collapseWhitespace(s)
+	}
+	return s
+}
+
+// namespace returns the XML namespace e belongs to.
+func namespace(e *Element) string {
+	switch e.Tag.(type) {
+	case SvgTag:
+		return svgNamespace
+	case MathTag:
+		return mathNamespace
+	}
+	return xhtmlNamespace
+}
+
+// usesXlink reports whether e or any of its descendants has an xlink:
+// attribute.
+func usesXlink(e *Element) bool {
+	for k := range e.Attributes {
+		if strings.HasPrefix(k, "xlink:") {
+			return true
+		}
+	}
+	for _, child := range e.Children {
+		if c, ok := child.(*Element); ok && usesXlink(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// needsNamespace reports whether e has to declare its namespace in XHTML
+// output because nothing around it already does.
+func (r *renderer) needsNamespace(e *Element) bool {
+	if _, ok := e.Attributes["xmlns"]; ok {
+		return false
+	}
+	parent := e.outerParent()
+	if e == r.top || parent == nil || parent.Tag.name() == "" {
+		return true
+	}
+	return namespace(parent) != namespace(e)
+}
+
+// openTag writes the start tag of e, including its attributes in sorted
+// order so output is stable between runs.
+func (r *renderer) openTag(e *Element) {
+	r.builder.WriteString("<")
+	r.builder.WriteString(e.Tag.name())
+
+	// Most elements have a handful of attributes; keep their keys on the
+	// stack.
+	var keyArray [8]string
+	keys := keyArray[:0]
+	for k := range e.Attributes {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	if r.opts.XHTML && r.needsNamespace(e) {
+		r.builder.WriteString(` xmlns="`)
+		r.builder.WriteString(namespace(e))
+		r.builder.WriteString(`"`)
+		if namespace(e) == svgNamespace && usesXlink(e) {
+			r.builder.WriteString(` xmlns:xlink="` + xlinkNamespace + `"`)
+		}
+	}
+
+	for _, k := range keys {
+		value := strings.Join(e.Attributes[k], " ")
+		if r.opts.XHTML {
+			value = xmlEscaper.Replace(value)
+			if booleanAttributes[k] && value == "" {
+				value = k
+			}
+		}
+		if r.opts.Minify {
+			if def, ok := defaultAttributeValues[e.Tag.name()][k]; ok && strings.EqualFold(value, def) {
+				continue
+			}
+		}
+
+		// Names are written as they are: setAttribute only stores names
+		// that pass validAttributeName, so none contains a character that
+		// would need escaping.
+		r.builder.WriteString(" ")
+		r.builder.WriteString(k)
+		switch {
+		case r.opts.Minify && value == "":
+		case r.opts.Minify && canUnquote(value):
+			r.builder.WriteString("=")
+			r.builder.WriteString(value)
+		default:
+			r.builder.WriteString(`="`)
+			r.builder.WriteString(value)
+			r.builder.WriteString(`"`)
+		}
+	}
+
+	if r.selfClosing(e) && (!r.opts.Minify || !e.isVoid()) {
+		r.builder.WriteString(" />")
+		return
+	}
+	r.builder.WriteString(">")
+}
+
+// canUnquote reports whether an attribute value may be written without
+// quotes. Values are already escaped, so only whitespace and the characters
+// that end or confuse an unquoted value need checking.
+func canUnquote(value string) bool {
+	return value != "" && !strings.ContainsAny(value, " \t\n\r\f\"'=<>`")
+}
+
+func (r *renderer) closeTag(e *Element) {
+	r.builder.WriteString("</")
+	r.builder.WriteString(e.Tag.name())
+	r.builder.WriteString(">")
+}
+
+// compact renders e on its own without any pretty printing.
+func (r *renderer) compact(e elementI) string {
+	sub := &renderer{builder: &bytes.Buffer{}, opts: r.opts, top: r.top}
+	sub.opts.Pretty = false
+	e.generateHtml(sub)
+	return sub.builder.String()
+}
+
+// flowsWithText reports whether e is an HTML element that pretty printing
+// keeps on the same line as its siblings: phrasing content that is not also
+// metadata, such as script or template.
+func flowsWithText(e *Element) bool {
+	switch e.Tag.(type) {
+	case NormalTag, VoidTag:
+	default:
+		return false
+	}
+
+	phrasing := false
+	for _, category := range elementCategories[e.Tag.name()] {
+		switch category {
+		case "phrasing":
+			phrasing = true
+		case "metadata":
+			return false
+		}
+	}
+	return phrasing
+}
+
+// isInline reports whether e, and everything inside it, flows with text.
+// A placeholder is judged by its content alone.
+func isInline(e *Element) bool {
+	if e.placeholder == "" && !flowsWithText(e) {
+		return false
+	}
+	for _, child := range e.Children {
+		c, ok := child.(*Element)
+		if !ok || !isInline(c) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasInlineContent reports whether all of e's content flows with text, so
+// it may be written on the same line as e's tags.
+func hasInlineContent(e *Element) bool {
+	for _, child := range e.Children {
+		c, ok := child.(*Element)
+		if !ok || !isInline(c) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *renderer) indent(depth int) {
+	for i := 0; i < depth; i++ {
+		r.builder.WriteString(r.opts.Indent)
+	}
+}
+
+// block writes e starting on a new line at the given depth.
+func (r *renderer) block(e *Element, depth int) {
+	if e.Tag.name() == "" {
+		r.children(e, depth)
+		return
+	}
+
+	if r.selfClosing(e) || preformattedTags[e.Tag.name()] {
+		r.indent(depth)
+		e.generateHtml(r)
+		r.builder.WriteString("\n")
+		return
+	}
+
+	if hasInlineContent(e) {
+		line := r.compact(e)
+		width := len(strings.Repeat(r.opts.Indent, depth)) + len(line)
+		if width <= r.opts.LineWidth && !strings.Contains(line, "\n") {
+			r.indent(depth)
+			r.builder.WriteString(line)
+			r.builder.WriteString("\n")
+			return
+		}
+	}
+
+	r.indent(depth)
+	r.openTag(e)
+	r.builder.WriteString("\n")
+	r.children(e, depth+1)
+	r.indent(depth)
+	r.closeTag(e)
+	r.builder.WriteString("\n")
+}
+
+// children writes the content of e at the given depth. Text and inline
+// children are gathered into runs that are wrapped together, while block
+// children each start on a line of their own.
+func (r *renderer) children(e *Element, depth int) {
+	run := &inlineRun{}
+	if e.Tag.name() != "" || e.placeholder != "" {
+		run.text(r.text(e.Content))
+	}
+
+	for _, child := range e.Children {
+		c, ok := child.(*Element)
+		if ok && isInline(c) {
+			run.element(r, c)
+			continue
+		}
+
+		r.wrap(run.words(), depth)
+		run = &inlineRun{}
+		if ok {
+			r.block(c, depth)
+		} else if n, isStatic := child.(*staticNode); isStatic {
+			n.block(r, depth)
+		} else {
+			r.indent(depth)
+			child.generateHtml(r)
+			r.builder.WriteString("\n")
+		}
+	}
+	r.wrap(run.words(), depth)
+}
+
+// wrap writes words separated by single spaces, starting a new line
+// whenever the next word would run past the line width.
+func (r *renderer) wrap(words []string, depth int) {
+	if len(words) == 0 {
+		return
+	}
+
+	prefix := strings.Repeat(r.opts.Indent, depth)
+	r.builder.WriteString(prefix)
+	column := len(prefix)
+	for i, word := range words {
+		if i > 0 {
+			if column+1+len(word) > r.opts.LineWidth {
+				r.builder.WriteString("\n")
+				r.builder.WriteString(prefix)
+				column = len(prefix)
+			} else {
+				r.builder.WriteString(" ")
+				column++
+			}
+		}
+		r.builder.WriteString(word)
+		column += len(word)
+	}
+	r.builder.WriteString("\n")
+}
+
+// inlineRun collects a sequence of text and inline elements as words. A
+// word boundary is only placed where the source already has whitespace, so
+// breaking lines between words never changes how the content renders.
+type inlineRun struct {
+	done    []string
+	current bytes.Buffer
+}
+
+func (run *inlineRun) text(s string) {
+	for _, c := range s {
+		switch c {
+		case ' ', '\t', '\n', '\r', '\f':
+			run.flush()
+		default:
+			run.current.WriteRune(c)
+		}
+	}
+}
+
+func (run *inlineRun) element(r *renderer, e *Element) {
+	if r.selfClosing(e) || preformattedTags[e.Tag.name()] {
+		run.current.WriteString(r.compact(e))
+		return
+	}
+
+	sub := &renderer{builder: &run.current, opts: r.opts, top: r.top}
+	if e.placeholder == "" {
+		sub.openTag(e)
+	}
+	run.text(r.text(e.Content))
+	for _, child := range e.Children {
+		run.element(r, child.(*Element))
+	}
+	if e.placeholder == "" {
+		sub.closeTag(e)
+	}
+}
+
+func (run *inlineRun) flush() {
+	if run.current.Len() > 0 {
+		run.done = append(run.done, run.current.String())
+		run.current.Reset()
+	}
+}
+
+func (run *inlineRun) words() []string {
+	run.flush()
+	return run.done
+}
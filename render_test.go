@@ -0,0 +1,277 @@
+package htmlsimple
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestMinifySubtreeKeepsOwnEndTag(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(g *Generator) *Element
+		want  string
+	}{
+		{
+			name: "li",
+			build: func(g *Generator) *Element {
+				ul := g.Root.Ul()
+				ul.Li().AddString("a")
+				return ul.Children[0].(*Element)
+			},
+			want: "<li>a</li>",
+		},
+		{
+			name: "p in footer",
+			build: func(g *Generator) *Element {
+				return g.Root.Footer().P().AddString("(c) Example")
+			},
+			want: "<p>(c) Example</p>",
+		},
+		{
+			name: "last td of rendered tr",
+			build: func(g *Generator) *Element {
+				tr := g.Root.Table().Tbody().Tr()
+				tr.Td().AddString("a")
+				tr.Td().AddString("b")
+				return tr
+			},
+			want: "<tr><td>a<td>b</tr>",
+		},
+		{
+			name: "li in fragment",
+			build: func(g *Generator) *Element {
+				f := g.Fragment()
+				f.Li().AddString("a")
+				f.Li().AddString("b")
+				return f
+			},
+			want: "<li>a<li>b</li>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := tt.build(New(nil))
+			if got := e.Render(RenderOptions{Minify: true}); got != tt.want {
+				t.Errorf("Render = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMinifyFrozenSubtreeKeepsOwnEndTag(t *testing.T) {
+	g := New(nil)
+	p := g.Root.Footer().P().AddString("(c) Example")
+	frozen := p.Freeze(RenderOptions{Minify: true})
+
+	page := New(nil)
+	body := page.Root.Body()
+	body.AddStatic(frozen, nil)
+	body.Span().AddString("after")
+
+	want := "<body><p>(c) Example</p><span>after</span></body>"
+	if got := body.Render(RenderOptions{Minify: true}); got != want {
+		t.Errorf("Render = %q, want %q", got, want)
+	}
+}
+
+// parseTree parses s as the body of a document and returns a canonical
+// dump of its DOM. Whitespace in text outside preformatted elements is
+// collapsed and attributes set to their default value are dropped, since
+// Minify changes neither how the document is shown nor how it behaves.
+func parseTree(t *testing.T, s string) string {
+	t.Helper()
+	doc, err := html.Parse(strings.NewReader(s))
+	if err != nil {
+		t.Fatalf("parse %q: %v", s, err)
+	}
+	var b strings.Builder
+	dumpTree(&b, doc, 0, false)
+	return b.String()
+}
+
+func dumpTree(b *strings.Builder, n *html.Node, depth int, pre bool) {
+	indent := strings.Repeat("  ", depth)
+	switch n.Type {
+	case html.TextNode:
+		text := n.Data
+		if !pre {
+			text = collapseWhitespace(text)
+		}
+		fmt.Fprintf(b, "%s%q\n", indent, text)
+	case html.ElementNode:
+		var attrs []string
+		for _, a := range n.Attr {
+			if def, ok := defaultAttributeValues[n.Data][a.Key]; ok && strings.EqualFold(a.Val, def) {
+				continue
+			}
+			attrs = append(attrs, fmt.Sprintf("%s=%q", a.Key, a.Val))
+		}
+		slices.Sort(attrs)
+		fmt.Fprintf(b, "%s<%s %s>\n", indent, n.Data, strings.Join(attrs, " "))
+		pre = pre || preformattedTags[n.Data]
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		dumpTree(b, c, depth+1, pre)
+	}
+}
+
+func TestMinifyPreservesDocument(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(body *Element)
+		want  string
+	}{
+		{
+			name: "list items",
+			build: func(body *Element) {
+				ul := body.Ul()
+				ul.Li().AddString("a")
+				ul.Li().AddString("b").Ul().Li().AddString("nested")
+				ul.Li().AddString("c")
+			},
+			want: "<ul><li>a<li>b<ul><li>nested</ul><li>c</ul></body>",
+		},
+		{
+			name: "table",
+			build: func(body *Element) {
+				table := body.Table()
+				table.Thead().Tr().Th().AddString("h")
+				tbody := table.Tbody()
+				for _, row := range []string{"1", "2"} {
+					tr := tbody.Tr()
+					tr.Td().AddString(row)
+					tr.Td().AddString(row)
+				}
+				table.Tfoot().Tr().Td().AddString("f")
+			},
+			want: "<table><thead><tr><th>h<tbody><tr><td>1<td>1<tr><td>2<td>2<tfoot><tr><td>f</table></body>",
+		},
+		{
+			name: "description list",
+			build: func(body *Element) {
+				dl := body.Dl()
+				dl.Dt().AddString("term")
+				dl.Dd().AddString("one")
+				dl.Dd().AddString("two")
+				dl.Dt().AddString("last")
+			},
+			want: "<dl><dt>term<dd>one<dd>two<dt>last</dt></dl></body>",
+		},
+		{
+			name: "select",
+			build: func(body *Element) {
+				sel := body.Select()
+				sel.Option().AddString("a")
+				group := sel.Optgroup().Attr("label", "g")
+				group.Option().AddString("b")
+				group.Option().AddString("c")
+			},
+			want: "<select><option>a<optgroup label=g><option>b<option>c</select></body>",
+		},
+		{
+			name: "paragraphs",
+			build: func(body *Element) {
+				body.P().AddString("one")
+				body.P().AddString("two")
+				body.Div().P().AddString("in div")
+				body.A().Attr("href", "/").P().AddString("in a")
+				body.Span().AddString("inline")
+				body.P().AddString("last")
+			},
+			want: `<p>one<p>two<div><p>in div</div><a href=/><p>in a</p></a><span>inline</span><p>last</body>`,
+		},
+		{
+			name: "paragraph in foreignObject",
+			build: func(body *Element) {
+				body.Svg().SvgForeignObject().P().AddString("inside")
+				body.Div().AddString("after")
+			},
+			want: "<svg><foreignObject><p>inside</p></foreignObject></svg><div>after</div></body>",
+		},
+		{
+			name: "paragraph before inline sibling",
+			build: func(body *Element) {
+				div := body.Div()
+				div.P().AddString("text")
+				div.Span().AddString("after")
+			},
+			want: "<div><p>text</p><span>after</span></div></body>",
+		},
+		{
+			name: "unquoted values",
+			build: func(body *Element) {
+				body.Div().WithAttrs(
+					KV("class", "a b"),
+					KV("title", "x=y"),
+					KV("lang", "en"),
+					KV("dir", ""),
+				)
+				body.Span().WithAttrs(
+					KV("title", `say "hi"`),
+					KV("class", "it's"),
+					KV("id", "a`b"),
+				)
+			},
+			want: `<div class="a b" dir lang=en title="x=y"></div><span class=it&#39;s id="a` + "`" + `b" title="say &#34;hi&#34;"></span></body>`,
+		},
+		{
+			name: "default attributes",
+			build: func(body *Element) {
+				form := body.Form().Attr("method", "GET")
+				form.Input().Attr("type", "text").Attr("name", "q")
+				form.Button().Attr("type", "submit").AddString("Go")
+				form.Button().Attr("type", "button").AddString("Reset")
+			},
+			want: "<form><input name=q><button>Go</button><button type=button>Reset</button></form></body>",
+		},
+		{
+			name: "whitespace",
+			build: func(body *Element) {
+				body.P().AddString("a   lot \n\t of\n\nspace")
+				body.Span().AddString(" edges ")
+			},
+			want: "<p>a lot of space</p><span> edges </span></body>",
+		},
+		{
+			name: "preformatted",
+			build: func(body *Element) {
+				body.Pre().AddString("\n  keep\n    this  ")
+				body.Textarea().AddString("  and\n\tthis")
+				body.Script().AddString("if (a  <  b) {\n}")
+			},
+			want: "<pre>\n  keep\n    this  </pre><textarea>  and\n\tthis</textarea><script>if (a  &lt;  b) {\n}</script></body>",
+		},
+		{
+			name: "ruby",
+			build: func(body *Element) {
+				ruby := body.Ruby().AddString("漢")
+				ruby.Rp().AddString("(")
+				ruby.Rt().AddString("kan")
+				ruby.Rp().AddString(")")
+			},
+			want: "<ruby>漢<rp>(<rt>kan<rp>)</ruby></body>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(nil)
+			tt.build(g.Root.Body())
+
+			compact := g.Generate()
+			minified := g.GenerateWith(RenderOptions{Minify: true})
+			if want := "<body>" + tt.want; minified != want {
+				t.Errorf("minified = %q, want %q", minified, want)
+			}
+
+			if got, want := parseTree(t, minified), parseTree(t, compact); got != want {
+				t.Errorf("minified DOM differs from compact DOM\ncompact:  %s\nminified: %s\n--- compact\n%s--- minified\n%s", compact, minified, want, got)
+			}
+		})
+	}
+}
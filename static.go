@@ -0,0 +1,206 @@
+package htmlsimple
+
+import (
+	"io"
+	"strings"
+)
+
+// Static is a subtree rendered once, ahead of time, into immutable HTML.
+// Headers, footers and navigation that are the same on every request can
+// be frozen once at startup and added to each page without building or
+// rendering them again. A Static holds no reference to the tree or the
+// Generator it was made from, so it may be shared between generators and
+// goroutines.
+//
+// Holes added with AddHole mark the places where content is supplied when
+// the Static is used.
+type Static struct {
+	// chunks are the rendered pieces between holes; there is always one
+	// more chunk than there are holes.
+	chunks []string
+	holes  []string
+	// indentable is set when s was frozen with Pretty and has no
+	// whitespace sensitive content, so its lines can be re-indented to the
+	// depth it is used at.
+	indentable bool
+	// stylesheets are those used in the frozen subtree, collected with the
+	// stylesheets of the tree the Static is added to.
+	stylesheets []*Stylesheet
+}
+
+// hole marks a named place in a tree that is filled when the tree is
+// frozen into a Static and used.
+type hole struct {
+	name string
+}
+
+// cut is a hole found while freezing, at an offset of the output.
+type cut struct {
+	name   string
+	offset int
+}
+
+// staticNode is a Static added to a tree together with its fills.
+type staticNode struct {
+	static *Static
+	fills  map[string]*Element
+}
+
+// AddHole adds a named hole to the current element and returns the current
+// element. A hole renders nothing in a normal tree; once the tree is frozen
+// with Freeze, the hole is where fills are rendered.
+//
+// Example:
+//
+//	header := g.Root.Header()
+//	header.A().Attr("href", "/").AddString("Home")
+//	header.AddHole("user")
+//	frozen := header.Freeze(htmlsimple.RenderOptions{})
+func (e *Element) AddHole(name string) *Element {
+	e.Children = append(e.Children, &hole{name: name})
+	return e
+}
+
+func (h *hole) generateHtml(r *renderer) {
+	if r.cuts != nil {
+		*r.cuts = append(*r.cuts, cut{name: h.name, offset: r.builder.Len()})
+	}
+}
+
+// Freeze renders e and its descendants according to opts and returns the
+// result as a Static. Later changes to e do not affect it. The stylesheets
+// used in e go with the Static, so the trees it is added to write them
+// with their collected styles.
+func (e *Element) Freeze(opts RenderOptions) *Static {
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	var cuts []cut
+	opts.Parallel = 0
+	e.renderWith(buf, opts, &cuts)
+
+	out := buf.String()
+	s := &Static{
+		indentable:  opts.Pretty && !opts.Minify && !containsPreformatted(e),
+		stylesheets: collectStylesheets(nil, e),
+	}
+	start := 0
+	for _, c := range cuts {
+		s.chunks = append(s.chunks, out[start:c.offset])
+		s.holes = append(s.holes, c.name)
+		start = c.offset
+	}
+	s.chunks = append(s.chunks, out[start:])
+	return s
+}
+
+// Holes returns the names of the holes of s in document order. A name
+// appears once for every hole carrying it.
+func (s *Static) Holes() []string {
+	return append([]string(nil), s.holes...)
+}
+
+// AddStatic adds s to the current element and returns the current element.
+// Each hole of s is filled by rendering the element fills holds for its
+// name, usually a Fragment; holes without a fill stay empty. Fills are
+// rendered with the options of the enclosing render but without pretty
+// printing, since the surrounding HTML is already rendered. A Static frozen
+// with Pretty is re-indented to where it is used in pretty output, unless
+// it contains whitespace sensitive elements such as <pre>.
+//
+// Example:
+//
+//	user := g.Fragment()
+//	user.Span().AddString(name)
+//	body.AddStatic(frozen, map[string]*htmlsimple.Element{"user": user})
+func (e *Element) AddStatic(s *Static, fills map[string]*Element) *Element {
+	for name := range fills {
+		if !s.hasHole(name) {
+			e.generator.report(e, SeverityWarning, "static content has no hole %q, its fill is not rendered", name)
+		}
+	}
+	e.Children = append(e.Children, &staticNode{static: s, fills: fills})
+	return e
+}
+
+// RenderTo writes s to w, filling its holes like AddStatic, without
+// building a tree around it.
+func (s *Static) RenderTo(w io.Writer, fills map[string]*Element, opts RenderOptions) error {
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	holder := &Element{Tag: NormalTag(""), Children: []elementI{&staticNode{static: s, fills: fills}}}
+	holder.render(buf, opts)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// block writes n on lines of its own at the given depth.
+func (n *staticNode) block(r *renderer, depth int) {
+	buf := getBuffer()
+	defer putBuffer(buf)
+	n.generateHtml(&renderer{builder: buf, opts: r.opts, top: r.top})
+
+	indentable := n.static.indentable
+	for _, fill := range n.fills {
+		if fill != nil && containsPreformatted(fill) {
+			indentable = false
+		}
+	}
+	if !indentable {
+		r.indent(depth)
+		r.builder.Write(buf.Bytes())
+		r.builder.WriteString("\n")
+		return
+	}
+
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		r.indent(depth)
+		r.builder.WriteString(line)
+		r.builder.WriteString("\n")
+	}
+}
+
+// containsPreformatted reports whether e or a descendant is whitespace
+// sensitive.
+func containsPreformatted(e *Element) bool {
+	if preformattedTags[e.Tag.name()] {
+		return true
+	}
+	for _, c := range e.Children {
+		if el, ok := c.(*Element); ok && containsPreformatted(el) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Static) hasHole(name string) bool {
+	for _, h := range s.holes {
+		if h == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *staticNode) generateHtml(r *renderer) {
+	fill := &renderer{builder: r.builder, opts: r.opts, top: r.top}
+	fill.opts.Pretty = false
+	for i, chunk := range n.static.chunks {
+		if i > 0 {
+			if el := n.fills[n.static.holes[i-1]]; el != nil {
+				el.generateHtml(fill)
+			}
+		}
+		if i == len(n.static.chunks)-1 && r.opts.Pretty && !r.opts.Minify {
+			// A Static frozen with Pretty ends with a newline, which is
+			// written again after this node.
+			chunk = strings.TrimSuffix(chunk, "\n")
+		}
+		r.builder.WriteString(chunk)
+	}
+}
@@ -0,0 +1,129 @@
+package htmlsimple
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestStatic(t *testing.T) {
+	tests := []struct {
+		name   string
+		freeze RenderOptions
+		opts   RenderOptions
+		fills  func(g *Generator) map[string]*Element
+		want   string
+		warns  bool
+	}{
+		{
+			name: "filled hole",
+			fills: func(g *Generator) map[string]*Element {
+				user := g.Fragment()
+				user.Span().AddString("Ann")
+				return map[string]*Element{"user": user}
+			},
+			want: `<body><header><a href="/">Home</a><span>Ann</span></header><p>after</p></body>`,
+		},
+		{
+			name: "empty hole",
+			want: `<body><header><a href="/">Home</a></header><p>after</p></body>`,
+		},
+		{
+			name: "fill without hole",
+			fills: func(g *Generator) map[string]*Element {
+				return map[string]*Element{"cart": g.Fragment()}
+			},
+			want:  `<body><header><a href="/">Home</a></header><p>after</p></body>`,
+			warns: true,
+		},
+		{
+			name:   "fills use the options of the page",
+			freeze: RenderOptions{Minify: true},
+			opts:   RenderOptions{Minify: true},
+			fills: func(g *Generator) map[string]*Element {
+				user := g.Fragment()
+				user.Span().Attr("class", "user").AddString("a   b")
+				return map[string]*Element{"user": user}
+			},
+			want: `<body><header><a href=/>Home</a><span class=user>a b</span></header><p>after</body>`,
+		},
+		{
+			name:   "pretty static is indented where it is used",
+			freeze: RenderOptions{Pretty: true},
+			opts:   RenderOptions{Pretty: true},
+			fills: func(g *Generator) map[string]*Element {
+				user := g.Fragment()
+				user.Span().AddString("Ann")
+				return map[string]*Element{"user": user}
+			},
+			want: "<body>\n  <header>\n    <a href=\"/\">Home</a>\n    <span>Ann</span>\n  </header>\n  <p>after</p>\n</body>\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := New(nil).Root.Header()
+			header.A().Attr("href", "/").AddString("Home")
+			header.AddHole("user")
+			frozen := header.Freeze(tt.freeze)
+			header.P().AddString("added after freezing")
+
+			g := New(nil)
+			var fills map[string]*Element
+			if tt.fills != nil {
+				fills = tt.fills(g)
+			}
+			body := g.Root.Body()
+			body.AddStatic(frozen, fills)
+			body.P().AddString("after")
+
+			if got := g.GenerateWith(tt.opts); got != tt.want {
+				t.Errorf("GenerateWith =\n%q\nwant\n%q", got, tt.want)
+			}
+			if warns := len(g.Diagnostics()) > 0; warns != tt.warns {
+				t.Errorf("diagnostics = %v, want warning %v", g.Diagnostics(), tt.warns)
+			}
+		})
+	}
+}
+
+func TestStaticHolesAndRenderTo(t *testing.T) {
+	div := New(nil).Root.Div()
+	div.AddHole("a")
+	div.P().AddString("mid")
+	div.AddHole("b")
+	div.AddHole("a")
+	frozen := div.Freeze(RenderOptions{})
+
+	if got, want := frozen.Holes(), []string{"a", "b", "a"}; !slices.Equal(got, want) {
+		t.Errorf("Holes = %q, want %q", got, want)
+	}
+
+	if got := div.Render(RenderOptions{}); got != `<div><p>mid</p></div>` {
+		t.Errorf("holes render in a normal tree: %q", got)
+	}
+
+	fill := New(nil).Fragment()
+	fill.B().AddString("X")
+	var buf bytes.Buffer
+	if err := frozen.RenderTo(&buf, map[string]*Element{"a": fill}, RenderOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), `<div><b>X</b><p>mid</p><b>X</b></div>`; got != want {
+		t.Errorf("RenderTo = %q, want %q", got, want)
+	}
+}
+
+func TestStaticKeepsPreformattedContent(t *testing.T) {
+	section := New(nil).Root.Section()
+	section.Pre().AddString("a\n  b")
+	frozen := section.Freeze(RenderOptions{Pretty: true})
+
+	g := New(nil)
+	g.Root.Div().AddStatic(frozen, nil)
+	got := g.GenerateWith(RenderOptions{Pretty: true})
+	if !strings.Contains(got, "<pre>a\n  b</pre>") {
+		t.Errorf("pre content was re-indented:\n%s", got)
+	}
+}
@@ -0,0 +1,334 @@
+package htmlsimple
+
+import (
+	"hash/fnv"
+	"html"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ScopeMode controls how the rules of a Stylesheet are limited to the
+// elements that use it.
+type ScopeMode int
+
+const (
+	// ScopeNone leaves the CSS as it is, applying to the whole page.
+	ScopeNone ScopeMode = iota
+	// ScopeClass gives elements using the stylesheet a generated class and
+	// limits every rule to descendants of such elements.
+	ScopeClass
+	// ScopeAttribute does the same with a generated data-* attribute,
+	// leaving the class attribute alone.
+	ScopeAttribute
+)
+
+// Stylesheet is CSS that belongs to a component. It is usually created once
+// in a package variable and attached with UseStylesheet to the root element
+// of every instance of the component. The stylesheets used in a tree are
+// collected when it is rendered and each is written once, wherever
+// AddCollectedStyles was called.
+//
+// In a scoped stylesheet every selector is limited to the inside of the
+// elements using it; the selector :scope refers to those elements
+// themselves. Rules inside @media, @supports, @container and @layer blocks
+// are scoped too, while @keyframes and @font-face are left alone.
+type Stylesheet struct {
+	css string
+	// scope is the generated class or attribute name, "" for ScopeNone.
+	scope string
+	mode  ScopeMode
+}
+
+// NewStylesheet returns a Stylesheet for css, scoped according to mode. The
+// generated scope name is derived from the CSS, so it is the same in every
+// process serving the page.
+//
+// Example:
+//
+//	var cardStyles = htmlsimple.NewStylesheet(`
+//		:scope { border: 1px solid #ddd; }
+//		h2 { font-size: 1.2rem; }
+//	`, htmlsimple.ScopeClass)
+//
+//	func card(parent *htmlsimple.Element, title string) *htmlsimple.Element {
+//		c := parent.Div().UseStylesheet(cardStyles)
+//		c.H2().AddString(title)
+//		return c
+//	}
+func NewStylesheet(css string, mode ScopeMode) *Stylesheet {
+	s := &Stylesheet{css: strings.TrimSpace(css), mode: mode}
+	if mode == ScopeNone {
+		return s
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(s.css))
+	s.scope = "s-" + strconv.FormatUint(uint64(h.Sum32()), 36)
+	selector := "." + s.scope
+	if mode == ScopeAttribute {
+		s.scope = "data-" + s.scope
+		selector = "[" + s.scope + "]"
+	}
+	s.css = strings.TrimSpace(scopeCSS(s.css, selector))
+	return s
+}
+
+// Scope returns the class or attribute name that scopes s, or "" when it
+// is not scoped.
+func (s *Stylesheet) Scope() string {
+	return s.scope
+}
+
+// CSS returns the CSS of s, with its selectors scoped.
+func (s *Stylesheet) CSS() string {
+	return s.css
+}
+
+// UseStylesheet marks the current element as using s and returns the
+// current element. The element is given the scope class or attribute of s,
+// and s is written by AddCollectedStyles and CSS as long as the element is
+// part of the tree, once however many elements use it.
+func (e *Element) UseStylesheet(s *Stylesheet) *Element {
+	switch s.mode {
+	case ScopeClass:
+		e.AddClass(s.scope)
+	case ScopeAttribute:
+		e.Attr(s.scope, "")
+	}
+	if !slices.Contains(e.stylesheets, s) {
+		e.stylesheets = append(e.stylesheets, s)
+	}
+	return e
+}
+
+// UseStylesheet marks the void element as using s. See
+// Element.UseStylesheet.
+func (v *VoidElement) UseStylesheet(s *Stylesheet) *VoidElement {
+	v.element.UseStylesheet(s)
+	return v
+}
+
+// CSS returns the CSS of every stylesheet used in the generator's tree, in
+// document order, for serving as an external file.
+func (g *Generator) CSS() string {
+	return stylesheetCSS(collectStylesheets(nil, g.Root))
+}
+
+// AddCollectedStyles adds a <style> element holding the CSS of every
+// stylesheet used in the tree to the current element, normally <head>, and
+// returns the current element. The CSS is gathered from the whole tree
+// when it is rendered, so components added after the head are included and
+// those removed, such as the default content of a filled placeholder, are
+// not. Nothing is written when no stylesheet is used.
+func (e *Element) AddCollectedStyles() *Element {
+	e.Children = append(e.Children, &stylesNode{})
+	return e
+}
+
+// collectStylesheets appends the stylesheets used by n and its descendants
+// to used, skipping those already in it. The stylesheets of an added Static
+// are collected together with those of its fills.
+func collectStylesheets(used []*Stylesheet, n elementI) []*Stylesheet {
+	switch n := n.(type) {
+	case *Element:
+		used = addStylesheets(used, n.stylesheets)
+		for _, c := range n.Children {
+			used = collectStylesheets(used, c)
+		}
+	case *staticNode:
+		used = addStylesheets(used, n.static.stylesheets)
+		for _, name := range n.static.holes {
+			if fill := n.fills[name]; fill != nil {
+				used = collectStylesheets(used, fill)
+			}
+		}
+	}
+	return used
+}
+
+// addStylesheets appends the stylesheets of add that are not in used yet,
+// comparing by CSS so equal stylesheets created twice are written once.
+func addStylesheets(used, add []*Stylesheet) []*Stylesheet {
+	for _, s := range add {
+		if !slices.ContainsFunc(used, func(u *Stylesheet) bool { return u == s || u.css == s.css }) {
+			used = append(used, s)
+		}
+	}
+	return used
+}
+
+func stylesheetCSS(stylesheets []*Stylesheet) string {
+	parts := make([]string, 0, len(stylesheets))
+	for _, s := range stylesheets {
+		parts = append(parts, s.css)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// stylesNode renders the stylesheets used in the tree it is part of.
+type stylesNode struct{}
+
+// styleEndTag matches what would end a <style> element early.
+var styleEndTag = regexp.MustCompile(`(?i)</style`)
+
+func (n *stylesNode) generateHtml(r *renderer) {
+	root := r.top
+	for root.Parent != nil {
+		root = root.Parent
+	}
+	css := stylesheetCSS(collectStylesheets(nil, root))
+	if css == "" {
+		return
+	}
+
+	// The contents of <style> are not escaped in HTML, so only a premature
+	// end tag needs guarding. "<\/style" means the same to CSS.
+	css = styleEndTag.ReplaceAllStringFunc(css, func(m string) string {
+		return "<\\/" + m[2:]
+	})
+	if r.opts.XHTML {
+		css = xmlEscaper.Replace(html.EscapeString(css))
+	}
+	r.builder.WriteString("<style>")
+	r.builder.WriteString(css)
+	r.builder.WriteString("</style>")
+}
+
+// scopeCSS limits the rules of css to elements matching selector and their
+// descendants, replacing :scope with selector.
+func scopeCSS(css, selector string) string {
+	var out strings.Builder
+	i := 0
+	for i < len(css) {
+		end := scanCSS(css, i, "{;}")
+		prelude := strings.TrimSpace(css[i:end])
+		if end == len(css) || css[end] != '{' {
+			// A statement such as @import, or a stray closing brace.
+			if prelude != "" {
+				out.WriteString(prelude)
+				if end < len(css) {
+					out.WriteByte(css[end])
+				}
+				out.WriteByte('\n')
+			}
+			i = end + 1
+			continue
+		}
+
+		close := matchingBrace(css, end)
+		body := css[end+1 : close]
+		switch {
+		case hasAtRule(prelude, "@media", "@supports", "@container", "@layer"):
+			out.WriteString(prelude + " {\n" + scopeCSS(body, selector) + "}\n")
+		case strings.HasPrefix(prelude, "@"):
+			out.WriteString(prelude + " {" + body + "}\n")
+		default:
+			out.WriteString(scopeSelectors(prelude, selector) + " {" + body + "}\n")
+		}
+		i = close + 1
+	}
+	return out.String()
+}
+
+// scopeSelectors scopes every selector of a comma separated list.
+func scopeSelectors(list, selector string) string {
+	var scoped []string
+	start := 0
+	for start <= len(list) {
+		end := scanCSS(list, start, ",")
+		s := strings.TrimSpace(list[start:end])
+		if strings.Contains(s, ":scope") {
+			s = strings.ReplaceAll(s, ":scope", selector)
+		} else if s != "" {
+			s = selector + " " + s
+		}
+		scoped = append(scoped, s)
+		start = end + 1
+	}
+	return strings.Join(scoped, ", ")
+}
+
+func hasAtRule(prelude string, names ...string) bool {
+	for _, name := range names {
+		if prelude == name || strings.HasPrefix(prelude, name+" ") || strings.HasPrefix(prelude, name+"(") {
+			return true
+		}
+	}
+	return false
+}
+
+// scanCSS returns the index of the first byte of stops in css at or after
+// i that is not inside a string, comment, parentheses or brackets, or
+// len(css).
+func scanCSS(css string, i int, stops string) int {
+	depth := 0
+	for i < len(css) {
+		c := css[i]
+		switch {
+		case c == '"' || c == '\'':
+			i = skipString(css, i)
+			continue
+		case c == '/' && strings.HasPrefix(css[i:], "/*"):
+			i = skipComment(css, i)
+			continue
+		case c == '(' || c == '[':
+			depth++
+		case c == ')' || c == ']':
+			depth--
+		case depth == 0 && strings.IndexByte(stops, c) >= 0:
+			return i
+		}
+		i++
+	}
+	return len(css)
+}
+
+// matchingBrace returns the index of the } closing the { at open, or
+// len(css) when it is missing.
+func matchingBrace(css string, open int) int {
+	depth := 0
+	for i := open; i < len(css); {
+		switch c := css[i]; {
+		case c == '"' || c == '\'':
+			i = skipString(css, i)
+			continue
+		case c == '/' && strings.HasPrefix(css[i:], "/*"):
+			i = skipComment(css, i)
+			continue
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+		i++
+	}
+	return len(css)
+}
+
+// skipString returns the index after the string starting at i.
+func skipString(css string, i int) int {
+	quote := css[i]
+	for i++; i < len(css); i++ {
+		switch css[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1
+		}
+	}
+	return len(css)
+}
+
+// skipComment returns the index after the comment starting at i.
+func skipComment(css string, i int) int {
+	end := strings.Index(css[i+2:], "*/")
+	if end < 0 {
+		return len(css)
+	}
+	return i + 2 + end + 2
+}
@@ -0,0 +1,198 @@
+package htmlsimple
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewStylesheet(t *testing.T) {
+	tests := []struct {
+		name string
+		css  string
+		mode ScopeMode
+		want string // with SCOPE standing for the scope selector
+	}{
+		{
+			name: "none",
+			css:  "  h2 { color: red; }  ",
+			mode: ScopeNone,
+			want: "h2 { color: red; }",
+		},
+		{
+			name: "class",
+			css:  ":scope { border: 0; } h2, p > a { color: red; }",
+			mode: ScopeClass,
+			want: "SCOPE { border: 0; }\nSCOPE h2, SCOPE p > a { color: red; }",
+		},
+		{
+			name: "attribute",
+			css:  "h2 { color: red; }",
+			mode: ScopeAttribute,
+			want: "SCOPE h2 { color: red; }",
+		},
+		{
+			name: "nested at-rules",
+			css:  "@media (min-width: 40em) { h2 { color: red; } } @keyframes spin { from { rotate: 0; } }",
+			mode: ScopeClass,
+			want: "@media (min-width: 40em) {\nSCOPE h2 { color: red; }\n}\n@keyframes spin { from { rotate: 0; } }",
+		},
+		{
+			name: "statements and strings",
+			css:  `@import "a.css"; a[title="x{y}"] { content: "}"; }`,
+			mode: ScopeClass,
+			want: `@import "a.css";` + "\n" + `SCOPE a[title="x{y}"] { content: "}"; }`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewStylesheet(tt.css, tt.mode)
+			var selector string
+			switch tt.mode {
+			case ScopeClass:
+				selector = "." + s.Scope()
+			case ScopeAttribute:
+				selector = "[" + s.Scope() + "]"
+				if !strings.HasPrefix(s.Scope(), "data-s-") {
+					t.Errorf("Scope = %q, want a data-s- attribute", s.Scope())
+				}
+			default:
+				if s.Scope() != "" {
+					t.Errorf("Scope = %q, want none", s.Scope())
+				}
+			}
+			if want := strings.ReplaceAll(tt.want, "SCOPE", selector); s.CSS() != want {
+				t.Errorf("CSS = %q, want %q", s.CSS(), want)
+			}
+			if again := NewStylesheet(tt.css, tt.mode); again.Scope() != s.Scope() {
+				t.Errorf("Scope differs between calls: %q and %q", s.Scope(), again.Scope())
+			}
+		})
+	}
+}
+
+func TestUseStylesheet(t *testing.T) {
+	class := NewStylesheet("p { margin: 0; }", ScopeClass)
+	attribute := NewStylesheet("p { margin: 0; }", ScopeAttribute)
+
+	g := New(nil)
+	g.Root.Div().UseStylesheet(class).UseStylesheet(class)
+	g.Root.Input().UseStylesheet(attribute)
+
+	want := `<div class="` + class.Scope() + `"></div><input ` + attribute.Scope() + `="" />`
+	if got := g.Generate(); got != want {
+		t.Errorf("Generate = %s, want %s", got, want)
+	}
+}
+
+func TestCollectedStyles(t *testing.T) {
+	red := NewStylesheet("h2 { color: red; }", ScopeNone)
+	blue := NewStylesheet("p { color: blue; }", ScopeNone)
+
+	tests := []struct {
+		name  string
+		build func(g *Generator, body *Element)
+		want  string
+	}{
+		{
+			name:  "none used",
+			build: func(g *Generator, body *Element) { body.P() },
+			want:  "",
+		},
+		{
+			name: "document order, once",
+			build: func(g *Generator, body *Element) {
+				body.P().UseStylesheet(blue)
+				body.H2().UseStylesheet(red)
+				body.P().UseStylesheet(blue)
+			},
+			want: "p { color: blue; }\nh2 { color: red; }",
+		},
+		{
+			name: "same css twice",
+			build: func(g *Generator, body *Element) {
+				body.H2().UseStylesheet(red)
+				body.H2().UseStylesheet(NewStylesheet("h2 { color: red; }", ScopeNone))
+			},
+			want: "h2 { color: red; }",
+		},
+		{
+			name: "placeholder default content",
+			build: func(g *Generator, body *Element) {
+				body.Placeholder("main").H2().UseStylesheet(red)
+			},
+			want: "h2 { color: red; }",
+		},
+		{
+			name: "placeholder filled",
+			build: func(g *Generator, body *Element) {
+				body.Placeholder("main").H2().UseStylesheet(red)
+				g.Fill("main", func(p *Element) { p.P().UseStylesheet(blue) })
+			},
+			want: "p { color: blue; }",
+		},
+		{
+			name: "element moved out",
+			build: func(g *Generator, body *Element) {
+				h2 := body.H2().UseStylesheet(red)
+				g.Fragment().Append(h2)
+			},
+			want: "",
+		},
+		{
+			name: "static from another generator",
+			build: func(g *Generator, body *Element) {
+				other := New(nil)
+				header := other.Root.Header().UseStylesheet(red)
+				header.AddHole("user")
+				user := g.Fragment()
+				user.P().UseStylesheet(blue)
+				body.AddStatic(header.Freeze(RenderOptions{}), map[string]*Element{"user": user})
+			},
+			want: "h2 { color: red; }\np { color: blue; }",
+		},
+		{
+			name: "end tag in css",
+			build: func(g *Generator, body *Element) {
+				body.P().UseStylesheet(NewStylesheet(`p::after { content: "</style>"; }`, ScopeNone))
+			},
+			want: `p::after { content: "<\/style>"; }`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(nil)
+			html := g.Root.Html()
+			html.Head().AddCollectedStyles()
+			body := html.Body()
+			tt.build(g, body)
+
+			style := ""
+			if tt.want != "" {
+				style = "<style>" + tt.want + "</style>"
+			}
+			want := "<html><head>" + style + "</head>" + body.Render(RenderOptions{}) + "</html>"
+			if got := g.Generate(); got != want {
+				t.Errorf("Generate = %s, want %s", got, want)
+			}
+			if got, want := g.CSS(), strings.ReplaceAll(tt.want, `<\/`, "</"); got != want {
+				t.Errorf("CSS = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestCollectedStylesInSubtree(t *testing.T) {
+	red := NewStylesheet("h2 { color: red; }", ScopeNone)
+
+	g := New(nil)
+	head := g.Root.Html().Head().AddCollectedStyles()
+	head.Parent.Body().H2().UseStylesheet(red)
+
+	// Rendering the head alone still writes the styles of the whole page.
+	want := "<head><style>h2 { color: red; }</style></head>"
+	if got := head.Render(RenderOptions{}); got != want {
+		t.Errorf("Render = %s, want %s", got, want)
+	}
+}
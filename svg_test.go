@@ -0,0 +1,72 @@
+package htmlsimple
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestSvgAttributes(t *testing.T) {
+	tests := []struct {
+		key, value string
+		want       string
+		warns      bool
+	}{
+		{"viewBox", "0 0 10 10", `<svg viewBox="0 0 10 10" />`, false},
+		{"preserveAspectRatio", "none", `<svg preserveAspectRatio="none" />`, false},
+		{"fill", "red", `<svg fill="red" />`, false},
+		{"href", "#icon", `<svg href="#icon" />`, false},
+		{"xlink:href", "https://example.com/a.svg", `<svg xlink:href="https://example.com/a.svg" />`, false},
+		{"href", "javascript:alert(1)", `<svg href="#" />`, true},
+		{"onclick", "alert(1)", `<svg data-onclick="alert(1)" />`, true},
+		{"viewbox", "0 0 1 1", `<svg data-viewbox="0 0 1 1" />`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key+"="+tt.value, func(t *testing.T) {
+			g := New(nil)
+			g.Root.Svg().Attr(tt.key, tt.value)
+			if got := g.Generate(); got != tt.want {
+				t.Errorf("Generate = %q, want %q", got, tt.want)
+			}
+			if warns := len(g.Diagnostics()) > 0; warns != tt.warns {
+				t.Errorf("diagnostics = %v, want warning %v", g.Diagnostics(), tt.warns)
+			}
+		})
+	}
+}
+
+func TestSvgParsesIntoSvgNamespace(t *testing.T) {
+	g := New(nil)
+	svg := g.Root.Body().Div().Svg().Attr("viewBox", "0 0 10 10")
+	svg.SvgCircle().Attr("r", "5")
+	svg.SvgForeignObject().Div().AddString("x")
+
+	doc, err := html.Parse(strings.NewReader(g.Generate()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	namespaces := map[string]string{}
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			namespaces[n.Data] += n.Namespace + ";"
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	for tag, want := range map[string]string{
+		"svg":           "svg;",
+		"circle":        "svg;",
+		"foreignObject": "svg;",
+		"div":           ";;",
+	} {
+		if got := namespaces[tag]; got != want {
+			t.Errorf("namespaces of %s = %q, want %q", tag, got, want)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package htmlsimple
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// Column describes one column of a table built with AddTable or
+// StreamTable.
+type Column[T any] struct {
+	// Header is the text of the column's <th>.
+	Header string
+	// Value returns the value of the column's cell for a row.
+	Value func(T) any
+	// Format turns a value into the text of its cell. Defaults to
+	// fmt.Sprint.
+	Format func(any) string
+	// Footer, when set on any column, adds a <tfoot> row with these texts.
+	Footer string
+	// SortKey makes the header a link built by TableOptions.SortURL.
+	SortKey string
+	// RowHeader renders the column's cells as <th scope="row">, usually
+	// for the first column.
+	RowHeader bool
+}
+
+// TableOptions configures a table built with AddTable or StreamTable.
+type TableOptions struct {
+	// Caption is rendered as the table's <caption>.
+	Caption string
+	// SortURL returns the link of a sortable column header. The URL is
+	// sanitized like any href, so return an absolute or root-relative URL.
+	SortURL func(key string, descending bool) string
+	// SortedBy and Descending describe the current order. The header of
+	// the sorted column links to the opposite direction.
+	SortedBy   string
+	Descending bool
+}
+
+// AddTable adds a <table> with a row per item of rows to parent and returns
+// it. Cell text is escaped like AddString.
+//
+// Example:
+//
+//	AddTable(parent, users, TableOptions{Caption: "Users"},
+//		Column[User]{Header: "Name", Value: func(u User) any { return u.Name }, RowHeader: true},
+//		Column[User]{Header: "Age", Value: func(u User) any { return u.Age }},
+//	)
+func AddTable[T any](parent *Element, rows []T, opts TableOptions, columns ...Column[T]) *Element {
+	table := parent.Table()
+	addTableHead(table, opts, columns)
+	body := table.Tbody()
+	for _, row := range rows {
+		addTableRow(body, row, columns)
+	}
+	addTableFoot(table, columns)
+	return table
+}
+
+// StreamTable writes a table to w, rendering and writing each row as soon
+// as rows yields it instead of building the whole table first. g supplies
+// the attribute policy. It stops at the first write error and returns it.
+func StreamTable[T any](w io.Writer, g *Generator, rows func(yield func(T) bool), opts TableOptions, columns ...Column[T]) error {
+	holder := &Element{Tag: NormalTag(""), generator: g}
+	table := holder.Table()
+	addTableHead(table, opts, columns)
+	body := table.Tbody()
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+	r := &renderer{builder: buf, top: table}
+	flush := func() error {
+		_, err := buf.WriteTo(w)
+		return err
+	}
+
+	r.openTag(table)
+	for _, child := range table.Children[:len(table.Children)-1] {
+		child.generateHtml(r)
+	}
+	r.openTag(body)
+	if err := flush(); err != nil {
+		return err
+	}
+
+	var err error
+	rows(func(row T) bool {
+		body.Children = body.Children[:0]
+		addTableRow(body, row, columns)
+		body.Children[0].generateHtml(r)
+		err = flush()
+		return err == nil
+	})
+	if err != nil {
+		return err
+	}
+
+	body.Children = nil
+	r.closeTag(body)
+	if foot := addTableFoot(table, columns); foot != nil {
+		foot.generateHtml(r)
+	}
+	r.closeTag(table)
+	return flush()
+}
+
+// ColumnsOf returns a column for every exported field of the struct type
+// T, in declaration order. The header is the field name, or the value of a
+// `table:"Header"` struct tag; `table:"-"` skips the field. It panics if T
+// is not a struct or pointer to struct.
+func ColumnsOf[T any]() []Column[T] {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	pointer := t.Kind() == reflect.Pointer
+	if pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("htmlsimple: ColumnsOf needs a struct type, got %s", t))
+	}
+
+	var columns []Column[T]
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		header := field.Tag.Get("table")
+		if !field.IsExported() || header == "-" {
+			continue
+		}
+		if header == "" {
+			header = field.Name
+		}
+
+		index := i
+		columns = append(columns, Column[T]{
+			Header: header,
+			Value: func(row T) any {
+				v := reflect.ValueOf(row)
+				if pointer {
+					if v.IsNil() {
+						return ""
+					}
+					v = v.Elem()
+				}
+				return v.Field(index).Interface()
+			},
+		})
+	}
+	return columns
+}
+
+func addTableHead[T any](table *Element, opts TableOptions, columns []Column[T]) {
+	if opts.Caption != "" {
+		table.Caption().AddString(opts.Caption)
+	}
+
+	tr := table.Thead().Tr()
+	for _, column := range columns {
+		th := tr.Th().Attr("scope", "col")
+		if column.SortKey == "" || opts.SortURL == nil {
+			th.AddString(column.Header)
+			continue
+		}
+
+		descending := column.SortKey == opts.SortedBy && !opts.Descending
+		th.A().Attr("href", opts.SortURL(column.SortKey, descending)).AddString(column.Header)
+		if column.SortKey == opts.SortedBy {
+			if opts.Descending {
+				th.Attr("class", "sorted-descending").Attr("aria-sort", "descending")
+			} else {
+				th.Attr("class", "sorted-ascending").Attr("aria-sort", "ascending")
+			}
+		}
+	}
+}
+
+func addTableRow[T any](body *Element, row T, columns []Column[T]) {
+	tr := body.Tr()
+	for _, column := range columns {
+		text := ""
+		if column.Value != nil {
+			value := column.Value(row)
+			if column.Format != nil {
+				text = column.Format(value)
+			} else {
+				text = fmt.Sprint(value)
+			}
+		}
+
+		if column.RowHeader {
+			tr.Th().Attr("scope", "row").AddString(text)
+		} else {
+			tr.Td().AddString(text)
+		}
+	}
+}
+
+// addTableFoot adds a <tfoot> if any column has a footer and returns it.
+func addTableFoot[T any](table *Element, columns []Column[T]) *Element {
+	hasFooter := false
+	for _, column := range columns {
+		if column.Footer != "" {
+			hasFooter = true
+		}
+	}
+	if !hasFooter {
+		return nil
+	}
+
+	tr := table.Tfoot().Tr()
+	for _, column := range columns {
+		tr.Td().AddString(column.Footer)
+	}
+	return tr.Parent
+}
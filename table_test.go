@@ -0,0 +1,156 @@
+package htmlsimple
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+type tableUser struct {
+	Name     string
+	Age      int    `table:"Years"`
+	Password string `table:"-"`
+	note     string
+}
+
+func TestAddTable(t *testing.T) {
+	users := []tableUser{{Name: "Ann", Age: 31}, {Name: "<Bob>", Age: 7}}
+	name := Column[tableUser]{Header: "Name", Value: func(u tableUser) any { return u.Name }, RowHeader: true}
+	age := Column[tableUser]{Header: "Age", Value: func(u tableUser) any { return u.Age }, SortKey: "age"}
+	sortURL := func(key string, descending bool) string {
+		return fmt.Sprintf("/users?sort=%s&desc=%v", key, descending)
+	}
+
+	tests := []struct {
+		name    string
+		rows    []tableUser
+		opts    TableOptions
+		columns []Column[tableUser]
+		want    string
+	}{
+		{
+			name:    "rows and row headers",
+			rows:    users,
+			columns: []Column[tableUser]{name, age},
+			want: `<table><thead><tr><th scope="col">Name</th><th scope="col">Age</th></tr></thead><tbody>` +
+				`<tr><th scope="row">Ann</th><td>31</td></tr><tr><th scope="row">&lt;Bob&gt;</th><td>7</td></tr></tbody></table>`,
+		},
+		{
+			name:    "caption, format and footer",
+			rows:    users[:1],
+			opts:    TableOptions{Caption: "Users"},
+			columns: []Column[tableUser]{{Header: "Age", Value: func(u tableUser) any { return u.Age }, Format: func(v any) string { return fmt.Sprintf("%d y", v) }, Footer: "Total"}},
+			want: `<table><caption>Users</caption><thead><tr><th scope="col">Age</th></tr></thead><tbody>` +
+				`<tr><td>31 y</td></tr></tbody><tfoot><tr><td>Total</td></tr></tfoot></table>`,
+		},
+		{
+			name:    "sortable header",
+			opts:    TableOptions{SortURL: sortURL},
+			columns: []Column[tableUser]{age},
+			want:    `<table><thead><tr><th scope="col"><a href="/users?sort=age&amp;desc=false">Age</a></th></tr></thead><tbody></tbody></table>`,
+		},
+		{
+			name:    "sorted ascending links to descending",
+			opts:    TableOptions{SortURL: sortURL, SortedBy: "age"},
+			columns: []Column[tableUser]{age},
+			want:    `<table><thead><tr><th aria-sort="ascending" class="sorted-ascending" scope="col"><a href="/users?sort=age&amp;desc=true">Age</a></th></tr></thead><tbody></tbody></table>`,
+		},
+		{
+			name:    "sorted descending",
+			opts:    TableOptions{SortURL: sortURL, SortedBy: "age", Descending: true},
+			columns: []Column[tableUser]{age},
+			want:    `<table><thead><tr><th aria-sort="descending" class="sorted-descending" scope="col"><a href="/users?sort=age&amp;desc=false">Age</a></th></tr></thead><tbody></tbody></table>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(nil)
+			AddTable(g.Root, tt.rows, tt.opts, tt.columns...)
+			if got := g.Generate(); got != tt.want {
+				t.Errorf("AddTable =\n%s\nwant\n%s", got, tt.want)
+			}
+
+			var buf bytes.Buffer
+			err := StreamTable(&buf, New(nil), rowsOf(tt.rows), tt.opts, tt.columns...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("StreamTable =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColumnsOf(t *testing.T) {
+	columns := ColumnsOf[tableUser]()
+	var headers []string
+	for _, c := range columns {
+		headers = append(headers, c.Header)
+	}
+	if want := []string{"Name", "Years"}; !slices.Equal(headers, want) {
+		t.Fatalf("headers = %q, want %q", headers, want)
+	}
+	if got := columns[1].Value(tableUser{Age: 5}); got != 5 {
+		t.Errorf("Value = %v, want 5", got)
+	}
+
+	pointers := ColumnsOf[*tableUser]()
+	if got := pointers[0].Value(&tableUser{Name: "Ann"}); got != "Ann" {
+		t.Errorf("Value = %v, want Ann", got)
+	}
+	if got := pointers[0].Value(nil); got != "" {
+		t.Errorf("Value of nil row = %v, want empty", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("ColumnsOf[int] did not panic")
+		}
+	}()
+	ColumnsOf[int]()
+}
+
+// rowsOf yields the items of rows, like slices.Values.
+func rowsOf[T any](rows []T) func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		for _, row := range rows {
+			if !yield(row) {
+				return
+			}
+		}
+	}
+}
+
+type failingWriter struct{ after int }
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.after == 0 {
+		return 0, errors.New("closed")
+	}
+	w.after--
+	return len(p), nil
+}
+
+func TestStreamTableStopsOnWriteError(t *testing.T) {
+	yielded := 0
+	rows := func(yield func(tableUser) bool) {
+		for i := 0; i < 10; i++ {
+			yielded++
+			if !yield(tableUser{Name: "x"}) {
+				return
+			}
+		}
+	}
+
+	err := StreamTable(&failingWriter{after: 2}, New(nil), rows, TableOptions{}, ColumnsOf[tableUser]()...)
+	if err == nil || err.Error() != "closed" {
+		t.Errorf("StreamTable error = %v, want closed", err)
+	}
+	if yielded != 2 {
+		t.Errorf("rows yielded = %d, want 2", yielded)
+	}
+}
@@ -2,654 +2,1545 @@
 
 package htmlsimple
 
-// Tag methods for HTML elements
+// Tag methods for HTML, SVG and MathML elements
 
 // A creates a <a> element and adds it to the current element.
+// A hyperlink to a web page, file, email address or location in the same page.
+//
+// Element-specific attributes: href, target, download, ping, rel, hreflang,
+// type, referrerpolicy.
 func (e *Element) A() *Element {
 	return e.Add(NormalTag("a"))
 }
 
 // Abbr creates a <abbr> element and adds it to the current element.
+// An abbreviation or acronym.
 func (e *Element) Abbr() *Element {
 	return e.Add(NormalTag("abbr"))
 }
 
 // Acronym creates a <acronym> element and adds it to the current element.
+// An acronym.
+//
+// Deprecated: Use Abbr instead.
 func (e *Element) Acronym() *Element {
 	return e.Add(NormalTag("acronym"))
 }
 
 // Address creates a <address> element and adds it to the current element.
+// Contact information for the nearest article or body.
 func (e *Element) Address() *Element {
 	return e.Add(NormalTag("address"))
 }
 
 // Area creates a void <area> element and adds it to the current element.
-func (e *Element) Area() *Element {
+// A clickable region inside an image map.
+//
+// Element-specific attributes: alt, coords, shape, href, target, download,
+// ping, rel, referrerpolicy.
+func (e *Element) Area() *VoidElement {
 	return e.AddVoid(VoidTag("area"))
 }
 
 // Article creates a <article> element and adds it to the current element.
+// A self-contained composition such as a post or comment.
 func (e *Element) Article() *Element {
 	return e.Add(NormalTag("article"))
 }
 
 // Aside creates a <aside> element and adds it to the current element.
+// Content only indirectly related to the main content.
 func (e *Element) Aside() *Element {
 	return e.Add(NormalTag("aside"))
 }
 
 // Audio creates a <audio> element and adds it to the current element.
+// Embedded sound content.
+//
+// Element-specific attributes: src, crossorigin, preload, autoplay, loop,
+// muted, controls.
 func (e *Element) Audio() *Element {
 	return e.Add(NormalTag("audio"))
 }
 
 // B creates a <b> element and adds it to the current element.
+// Text drawn to the reader's attention without extra importance.
 func (e *Element) B() *Element {
 	return e.Add(NormalTag("b"))
 }
 
 // Base creates a void <base> element and adds it to the current element.
-func (e *Element) Base() *Element {
+// The base URL for all relative URLs in the document.
+//
+// Element-specific attributes: href, target.
+func (e *Element) Base() *VoidElement {
 	return e.AddVoid(VoidTag("base"))
 }
 
 // Bdi creates a <bdi> element and adds it to the current element.
+// Text isolated from the surrounding bidirectional algorithm.
 func (e *Element) Bdi() *Element {
 	return e.Add(NormalTag("bdi"))
 }
 
 // Bdo creates a <bdo> element and adds it to the current element.
+// Text with an overridden writing direction.
 func (e *Element) Bdo() *Element {
 	return e.Add(NormalTag("bdo"))
 }
 
 // Big creates a <big> element and adds it to the current element.
+// Text one size larger than the surrounding text.
+//
+// Deprecated: Use CSS font-size instead.
 func (e *Element) Big() *Element {
 	return e.Add(NormalTag("big"))
 }
 
 // Blockquote creates a <blockquote> element and adds it to the current element.
+// A quotation from another source.
+//
+// Element-specific attributes: cite.
 func (e *Element) Blockquote() *Element {
 	return e.Add(NormalTag("blockquote"))
 }
 
 // Body creates a <body> element and adds it to the current element.
+// The content of the document.
 func (e *Element) Body() *Element {
 	return e.Add(NormalTag("body"))
 }
 
 // Br creates a void <br> element and adds it to the current element.
-func (e *Element) Br() *Element {
+// A line break in text.
+func (e *Element) Br() *VoidElement {
 	return e.AddVoid(VoidTag("br"))
 }
 
 // Button creates a <button> element and adds it to the current element.
+// A clickable button.
+//
+// Element-specific attributes: disabled, form, formaction, formenctype,
+// formmethod, formnovalidate, formtarget, name, popovertarget,
+// popovertargetaction, type, value.
 func (e *Element) Button() *Element {
 	return e.Add(NormalTag("button"))
 }
 
 // Canvas creates a <canvas> element and adds it to the current element.
+// A drawing surface for scripts.
+//
+// Element-specific attributes: width, height.
 func (e *Element) Canvas() *Element {
 	return e.Add(NormalTag("canvas"))
 }
 
 // Caption creates a <caption> element and adds it to the current element.
+// The title of a table.
 func (e *Element) Caption() *Element {
 	return e.Add(NormalTag("caption"))
 }
 
 // Center creates a <center> element and adds it to the current element.
+// Content centered horizontally.
+//
+// Deprecated: Use CSS text-align or margins instead.
 func (e *Element) Center() *Element {
 	return e.Add(NormalTag("center"))
 }
 
 // Cite creates a <cite> element and adds it to the current element.
+// The title of a creative work.
 func (e *Element) Cite() *Element {
 	return e.Add(NormalTag("cite"))
 }
 
 // Code creates a <code> element and adds it to the current element.
+// A fragment of computer code.
 func (e *Element) Code() *Element {
 	return e.Add(NormalTag("code"))
 }
 
 // Col creates a void <col> element and adds it to the current element.
-func (e *Element) Col() *Element {
+// A column within a colgroup.
+//
+// Element-specific attributes: span.
+func (e *Element) Col() *VoidElement {
 	return e.AddVoid(VoidTag("col"))
 }
 
 // Colgroup creates a <colgroup> element and adds it to the current element.
+// A group of columns within a table.
+//
+// Element-specific attributes: span.
 func (e *Element) Colgroup() *Element {
 	return e.Add(NormalTag("colgroup"))
 }
 
 // Data creates a <data> element and adds it to the current element.
+// Content linked to a machine-readable value.
+//
+// Element-specific attributes: value.
 func (e *Element) Data() *Element {
 	return e.Add(NormalTag("data"))
 }
 
 // Datalist creates a <datalist> element and adds it to the current element.
+// A set of suggested options for an input.
 func (e *Element) Datalist() *Element {
 	return e.Add(NormalTag("datalist"))
 }
 
 // Dd creates a <dd> element and adds it to the current element.
+// The description of a term in a description list.
 func (e *Element) Dd() *Element {
 	return e.Add(NormalTag("dd"))
 }
 
 // Del creates a <del> element and adds it to the current element.
+// Text that has been removed from the document.
+//
+// Element-specific attributes: cite, datetime.
 func (e *Element) Del() *Element {
 	return e.Add(NormalTag("del"))
 }
 
 // Details creates a <details> element and adds it to the current element.
+// A disclosure widget that can be opened and closed.
+//
+// Element-specific attributes: open, name.
 func (e *Element) Details() *Element {
 	return e.Add(NormalTag("details"))
 }
 
 // Dfn creates a <dfn> element and adds it to the current element.
+// The defining instance of a term.
 func (e *Element) Dfn() *Element {
 	return e.Add(NormalTag("dfn"))
 }
 
 // Dialog creates a <dialog> element and adds it to the current element.
+// A dialog box or other interactive component.
+//
+// Element-specific attributes: open.
 func (e *Element) Dialog() *Element {
 	return e.Add(NormalTag("dialog"))
 }
 
 // Dir creates a <dir> element and adds it to the current element.
+// A directory list.
+//
+// Deprecated: Use Ul instead.
 func (e *Element) Dir() *Element {
 	return e.Add(NormalTag("dir"))
 }
 
 // Div creates a <div> element and adds it to the current element.
+// A generic container for flow content.
 func (e *Element) Div() *Element {
 	return e.Add(NormalTag("div"))
 }
 
 // Dl creates a <dl> element and adds it to the current element.
+// A description list of term and description groups.
 func (e *Element) Dl() *Element {
 	return e.Add(NormalTag("dl"))
 }
 
 // Dt creates a <dt> element and adds it to the current element.
+// A term in a description list.
 func (e *Element) Dt() *Element {
 	return e.Add(NormalTag("dt"))
 }
 
 // Em creates a <em> element and adds it to the current element.
+// Stressed emphasis.
 func (e *Element) Em() *Element {
 	return e.Add(NormalTag("em"))
 }
 
 // Embed creates a void <embed> element and adds it to the current element.
-func (e *Element) Embed() *Element {
+// External content provided by a plugin or application.
+//
+// Element-specific attributes: src, type, width, height.
+func (e *Element) Embed() *VoidElement {
 	return e.AddVoid(VoidTag("embed"))
 }
 
 // Fencedframe creates a <fencedframe> element and adds it to the current element.
+// A nested browsing context with limited communication, used for
+// privacy-preserving ads.
+//
+// Element-specific attributes: allow, width, height.
 func (e *Element) Fencedframe() *Element {
 	return e.Add(NormalTag("fencedframe"))
 }
 
 // Fieldset creates a <fieldset> element and adds it to the current element.
+// A group of form controls with an optional legend.
+//
+// Element-specific attributes: disabled, form, name.
 func (e *Element) Fieldset() *Element {
 	return e.Add(NormalTag("fieldset"))
 }
 
 // Figcaption creates a <figcaption> element and adds it to the current element.
+// A caption for the parent figure.
 func (e *Element) Figcaption() *Element {
 	return e.Add(NormalTag("figcaption"))
 }
 
 // Figure creates a <figure> element and adds it to the current element.
+// Self-contained content, optionally with a caption.
 func (e *Element) Figure() *Element {
 	return e.Add(NormalTag("figure"))
 }
 
 // Font creates a <font> element and adds it to the current element.
+// Text with a font, size and color.
+//
+// Element-specific attributes: color, face, size.
+//
+// Deprecated: Use CSS instead.
 func (e *Element) Font() *Element {
 	return e.Add(NormalTag("font"))
 }
 
 // Footer creates a <footer> element and adds it to the current element.
+// A footer for the nearest sectioning content or the page.
 func (e *Element) Footer() *Element {
 	return e.Add(NormalTag("footer"))
 }
 
 // Form creates a <form> element and adds it to the current element.
+// A form that submits information to a server.
+//
+// Element-specific attributes: accept-charset, action, autocomplete, enctype,
+// method, name, novalidate, target, rel.
 func (e *Element) Form() *Element {
 	return e.Add(NormalTag("form"))
 }
 
 // Frame creates a <frame> element and adds it to the current element.
+// A frame within a frameset.
+//
+// Element-specific attributes: src, name.
+//
+// Deprecated: Framesets are obsolete; use Iframe instead.
 func (e *Element) Frame() *Element {
 	return e.Add(NormalTag("frame"))
 }
 
 // Frameset creates a <frameset> element and adds it to the current element.
+// A container of frames.
+//
+// Element-specific attributes: cols, rows.
+//
+// Deprecated: Framesets are obsolete; use Iframe instead.
 func (e *Element) Frameset() *Element {
 	return e.Add(NormalTag("frameset"))
 }
 
 // H1 creates a <h1> element and adds it to the current element.
+// A level 1 section heading.
 func (e *Element) H1() *Element {
 	return e.Add(NormalTag("h1"))
 }
 
+// H2 creates a <h2> element and adds it to the current element.
+// A level 2 section heading.
+func (e *Element) H2() *Element {
+	return e.Add(NormalTag("h2"))
+}
+
+// H3 creates a <h3> element and adds it to the current element.
+// A level 3 section heading.
+func (e *Element) H3() *Element {
+	return e.Add(NormalTag("h3"))
+}
+
+// H4 creates a <h4> element and adds it to the current element.
+// A level 4 section heading.
+func (e *Element) H4() *Element {
+	return e.Add(NormalTag("h4"))
+}
+
+// H5 creates a <h5> element and adds it to the current element.
+// A level 5 section heading.
+func (e *Element) H5() *Element {
+	return e.Add(NormalTag("h5"))
+}
+
+// H6 creates a <h6> element and adds it to the current element.
+// A level 6 section heading.
+func (e *Element) H6() *Element {
+	return e.Add(NormalTag("h6"))
+}
+
 // Head creates a <head> element and adds it to the current element.
+// Metadata about the document.
 func (e *Element) Head() *Element {
 	return e.Add(NormalTag("head"))
 }
 
 // Header creates a <header> element and adds it to the current element.
+// Introductory content for the nearest sectioning content or the page.
 func (e *Element) Header() *Element {
 	return e.Add(NormalTag("header"))
 }
 
 // Hgroup creates a <hgroup> element and adds it to the current element.
+// A heading grouped with related subheadings or taglines.
 func (e *Element) Hgroup() *Element {
 	return e.Add(NormalTag("hgroup"))
 }
 
 // Hr creates a void <hr> element and adds it to the current element.
-func (e *Element) Hr() *Element {
+// A thematic break between paragraphs.
+func (e *Element) Hr() *VoidElement {
 	return e.AddVoid(VoidTag("hr"))
 }
 
 // Html creates a <html> element and adds it to the current element.
+// The root of an HTML document.
 func (e *Element) Html() *Element {
 	return e.Add(NormalTag("html"))
 }
 
 // I creates a <i> element and adds it to the current element.
+// Text in an alternate voice or mood.
 func (e *Element) I() *Element {
 	return e.Add(NormalTag("i"))
 }
 
 // Iframe creates a <iframe> element and adds it to the current element.
+// A nested browsing context embedding another page.
+//
+// Element-specific attributes: src, srcdoc, name, sandbox, allow,
+// allowfullscreen, width, height, referrerpolicy, loading.
 func (e *Element) Iframe() *Element {
 	return e.Add(NormalTag("iframe"))
 }
 
 // Img creates a void <img> element and adds it to the current element.
-func (e *Element) Img() *Element {
+// An image.
+//
+// Element-specific attributes: alt, src, srcset, sizes, crossorigin, usemap,
+// ismap, width, height, referrerpolicy, decoding, loading, fetchpriority.
+func (e *Element) Img() *VoidElement {
 	return e.AddVoid(VoidTag("img"))
 }
 
 // Input creates a void <input> element and adds it to the current element.
-func (e *Element) Input() *Element {
+// A typed data field for user input.
+//
+// Element-specific attributes: accept, alt, autocomplete, checked, dirname,
+// disabled, form, formaction, formenctype, formmethod, formnovalidate,
+// formtarget, height, list, max, maxlength, min, minlength, multiple, name,
+// pattern, placeholder, readonly, required, size, src, step, type, value,
+// width.
+func (e *Element) Input() *VoidElement {
 	return e.AddVoid(VoidTag("input"))
 }
 
 // Ins creates a <ins> element and adds it to the current element.
+// Text that has been added to the document.
+//
+// Element-specific attributes: cite, datetime.
 func (e *Element) Ins() *Element {
 	return e.Add(NormalTag("ins"))
 }
 
 // Kbd creates a <kbd> element and adds it to the current element.
+// User input such as keyboard keys.
 func (e *Element) Kbd() *Element {
 	return e.Add(NormalTag("kbd"))
 }
 
 // Label creates a <label> element and adds it to the current element.
+// A caption for a form control.
+//
+// Element-specific attributes: for.
 func (e *Element) Label() *Element {
 	return e.Add(NormalTag("label"))
 }
 
 // Legend creates a <legend> element and adds it to the current element.
+// A caption for the parent fieldset.
 func (e *Element) Legend() *Element {
 	return e.Add(NormalTag("legend"))
 }
 
 // Li creates a <li> element and adds it to the current element.
+// An item in a list.
+//
+// Element-specific attributes: value.
 func (e *Element) Li() *Element {
 	return e.Add(NormalTag("li"))
 }
 
 // Link creates a void <link> element and adds it to the current element.
-func (e *Element) Link() *Element {
+// A relationship to an external resource such as a stylesheet.
+//
+// Element-specific attributes: href, crossorigin, rel, media, integrity,
+// hreflang, type, referrerpolicy, sizes, as, blocking, color, disabled,
+// fetchpriority.
+func (e *Element) Link() *VoidElement {
 	return e.AddVoid(VoidTag("link"))
 }
 
 // Main creates a <main> element and adds it to the current element.
+// The dominant content of the document.
 func (e *Element) Main() *Element {
 	return e.Add(NormalTag("main"))
 }
 
 // Map creates a <map> element and adds it to the current element.
+// An image map, used together with area elements.
+//
+// Element-specific attributes: name.
 func (e *Element) Map() *Element {
 	return e.Add(NormalTag("map"))
 }
 
 // Mark creates a <mark> element and adds it to the current element.
+// Text highlighted for reference.
 func (e *Element) Mark() *Element {
 	return e.Add(NormalTag("mark"))
 }
 
 // Marquee creates a <marquee> element and adds it to the current element.
+// Scrolling text.
+//
+// Element-specific attributes: behavior, direction, loop, scrollamount,
+// scrolldelay.
+//
+// Deprecated: Use CSS animations instead.
 func (e *Element) Marquee() *Element {
 	return e.Add(NormalTag("marquee"))
 }
 
-// Math creates a <math> element and adds it to the current element.
-func (e *Element) Math() *Element {
-	return e.Add(NormalTag("math"))
-}
-
 // Menu creates a <menu> element and adds it to the current element.
+// A list of commands, semantically equivalent to ul.
 func (e *Element) Menu() *Element {
 	return e.Add(NormalTag("menu"))
 }
 
 // Meta creates a void <meta> element and adds it to the current element.
-func (e *Element) Meta() *Element {
+// Metadata that cannot be expressed by other metadata elements.
+//
+// Element-specific attributes: name, http-equiv, content, charset, media.
+func (e *Element) Meta() *VoidElement {
 	return e.AddVoid(VoidTag("meta"))
 }
 
 // Meter creates a <meter> element and adds it to the current element.
+// A scalar value within a known range.
+//
+// Element-specific attributes: value, min, max, low, high, optimum.
 func (e *Element) Meter() *Element {
 	return e.Add(NormalTag("meter"))
 }
 
 // Nav creates a <nav> element and adds it to the current element.
+// A section of navigation links.
 func (e *Element) Nav() *Element {
 	return e.Add(NormalTag("nav"))
 }
 
 // Nobr creates a <nobr> element and adds it to the current element.
+// Text that must not wrap.
+//
+// Deprecated: Use CSS white-space instead.
 func (e *Element) Nobr() *Element {
 	return e.Add(NormalTag("nobr"))
 }
 
 // Noembed creates a <noembed> element and adds it to the current element.
+// Fallback content for embed.
+//
+// Deprecated: Use Object fallback content instead.
 func (e *Element) Noembed() *Element {
 	return e.Add(NormalTag("noembed"))
 }
 
 // Noframes creates a <noframes> element and adds it to the current element.
+// Fallback content for browsers without frame support.
+//
+// Deprecated: Framesets are obsolete.
 func (e *Element) Noframes() *Element {
 	return e.Add(NormalTag("noframes"))
 }
 
 // Noscript creates a <noscript> element and adds it to the current element.
+// Content shown when scripting is disabled.
 func (e *Element) Noscript() *Element {
 	return e.Add(NormalTag("noscript"))
 }
 
 // Object creates a <object> element and adds it to the current element.
+// An external resource such as an image, page or plugin.
+//
+// Element-specific attributes: data, type, name, form, width, height.
 func (e *Element) Object() *Element {
 	return e.Add(NormalTag("object"))
 }
 
 // Ol creates a <ol> element and adds it to the current element.
+// An ordered list.
+//
+// Element-specific attributes: reversed, start, type.
 func (e *Element) Ol() *Element {
 	return e.Add(NormalTag("ol"))
 }
 
 // Optgroup creates a <optgroup> element and adds it to the current element.
+// A group of options within a select.
+//
+// Element-specific attributes: disabled, label.
 func (e *Element) Optgroup() *Element {
 	return e.Add(NormalTag("optgroup"))
 }
 
 // Option creates a <option> element and adds it to the current element.
+// An option in a select or datalist.
+//
+// Element-specific attributes: disabled, label, selected, value.
 func (e *Element) Option() *Element {
 	return e.Add(NormalTag("option"))
 }
 
 // Output creates a <output> element and adds it to the current element.
+// The result of a calculation or user action.
+//
+// Element-specific attributes: for, form, name.
 func (e *Element) Output() *Element {
 	return e.Add(NormalTag("output"))
 }
 
 // P creates a <p> element and adds it to the current element.
+// A paragraph.
 func (e *Element) P() *Element {
 	return e.Add(NormalTag("p"))
 }
 
 // Param creates a void <param> element and adds it to the current element.
-func (e *Element) Param() *Element {
+// A parameter for an object.
+//
+// Element-specific attributes: name, value.
+//
+// Deprecated: Use the data attribute of Object instead.
+func (e *Element) Param() *VoidElement {
 	return e.AddVoid(VoidTag("param"))
 }
 
 // Picture creates a <picture> element and adds it to the current element.
+// Alternative sources for a contained img.
 func (e *Element) Picture() *Element {
 	return e.Add(NormalTag("picture"))
 }
 
 // Plaintext creates a <plaintext> element and adds it to the current element.
+// Everything after the start tag as plain text.
+//
+// Deprecated: Use Pre instead.
 func (e *Element) Plaintext() *Element {
 	return e.Add(NormalTag("plaintext"))
 }
 
 // Portal creates a <portal> element and adds it to the current element.
+// An embedded page that can be activated.
+//
+// Element-specific attributes: src, referrerpolicy.
+//
+// Deprecated: The portal proposal has been abandoned.
 func (e *Element) Portal() *Element {
 	return e.Add(NormalTag("portal"))
 }
 
 // Pre creates a <pre> element and adds it to the current element.
+// Preformatted text.
 func (e *Element) Pre() *Element {
 	return e.Add(NormalTag("pre"))
 }
 
 // Progress creates a <progress> element and adds it to the current element.
+// The completion progress of a task.
+//
+// Element-specific attributes: value, max.
 func (e *Element) Progress() *Element {
 	return e.Add(NormalTag("progress"))
 }
 
 // Q creates a <q> element and adds it to the current element.
+// A short inline quotation.
+//
+// Element-specific attributes: cite.
 func (e *Element) Q() *Element {
 	return e.Add(NormalTag("q"))
 }
 
 // Rb creates a <rb> element and adds it to the current element.
+// A base text component of a ruby annotation.
+//
+// Deprecated: Put base text directly in Ruby instead.
 func (e *Element) Rb() *Element {
 	return e.Add(NormalTag("rb"))
 }
 
 // Rp creates a <rp> element and adds it to the current element.
+// Fallback parentheses for browsers without ruby support.
 func (e *Element) Rp() *Element {
 	return e.Add(NormalTag("rp"))
 }
 
 // Rt creates a <rt> element and adds it to the current element.
+// The annotation text of a ruby annotation.
 func (e *Element) Rt() *Element {
 	return e.Add(NormalTag("rt"))
 }
 
 // Rtc creates a <rtc> element and adds it to the current element.
+// A container for semantic ruby annotations.
+//
+// Deprecated: Use Rt instead.
 func (e *Element) Rtc() *Element {
 	return e.Add(NormalTag("rtc"))
 }
 
 // Ruby creates a <ruby> element and adds it to the current element.
+// A ruby annotation for East Asian typography.
 func (e *Element) Ruby() *Element {
 	return e.Add(NormalTag("ruby"))
 }
 
 // S creates a <s> element and adds it to the current element.
+// Text that is no longer accurate or relevant.
 func (e *Element) S() *Element {
 	return e.Add(NormalTag("s"))
 }
 
 // Samp creates a <samp> element and adds it to the current element.
+// Sample output from a computer program.
 func (e *Element) Samp() *Element {
 	return e.Add(NormalTag("samp"))
 }
 
 // Script creates a <script> element and adds it to the current element.
+// An embedded or referenced script.
+//
+// Element-specific attributes: src, type, nomodule, async, defer, crossorigin,
+// integrity, referrerpolicy, blocking, fetchpriority.
 func (e *Element) Script() *Element {
 	return e.Add(NormalTag("script"))
 }
 
 // Search creates a <search> element and adds it to the current element.
+// A search or filtering section.
 func (e *Element) Search() *Element {
 	return e.Add(NormalTag("search"))
 }
 
 // Section creates a <section> element and adds it to the current element.
+// A generic standalone section of a document.
 func (e *Element) Section() *Element {
 	return e.Add(NormalTag("section"))
 }
 
 // Select creates a <select> element and adds it to the current element.
+// A control offering a menu of options.
+//
+// Element-specific attributes: autocomplete, disabled, form, multiple, name,
+// required, size.
 func (e *Element) Select() *Element {
 	return e.Add(NormalTag("select"))
 }
 
 // Slot creates a <slot> element and adds it to the current element.
+// A placeholder inside a shadow tree filled with light DOM content.
+//
+// Element-specific attributes: name.
 func (e *Element) Slot() *Element {
 	return e.Add(NormalTag("slot"))
 }
 
 // Small creates a <small> element and adds it to the current element.
+// Side comments and small print.
 func (e *Element) Small() *Element {
 	return e.Add(NormalTag("small"))
 }
 
 // Source creates a void <source> element and adds it to the current element.
-func (e *Element) Source() *Element {
+// A media resource for picture, audio or video.
+//
+// Element-specific attributes: type, media, src, srcset, sizes, width, height.
+func (e *Element) Source() *VoidElement {
 	return e.AddVoid(VoidTag("source"))
 }
 
 // Span creates a <span> element and adds it to the current element.
+// A generic inline container.
 func (e *Element) Span() *Element {
 	return e.Add(NormalTag("span"))
 }
 
 // Strike creates a <strike> element and adds it to the current element.
+// Text that has been struck through.
+//
+// Deprecated: Use S or Del instead.
 func (e *Element) Strike() *Element {
 	return e.Add(NormalTag("strike"))
 }
 
 // Strong creates a <strong> element and adds it to the current element.
+// Text of strong importance.
 func (e *Element) Strong() *Element {
 	return e.Add(NormalTag("strong"))
 }
 
 // Style creates a <style> element and adds it to the current element.
+// Style information for the document.
+//
+// Element-specific attributes: media, blocking.
 func (e *Element) Style() *Element {
 	return e.Add(NormalTag("style"))
 }
 
 // Sub creates a <sub> element and adds it to the current element.
+// Subscript text.
 func (e *Element) Sub() *Element {
 	return e.Add(NormalTag("sub"))
 }
 
 // Summary creates a <summary> element and adds it to the current element.
+// The summary or legend of a details element.
 func (e *Element) Summary() *Element {
 	return e.Add(NormalTag("summary"))
 }
 
 // Sup creates a <sup> element and adds it to the current element.
+// Superscript text.
 func (e *Element) Sup() *Element {
 	return e.Add(NormalTag("sup"))
 }
 
-// Svg creates a <svg> element and adds it to the current element.
-func (e *Element) Svg() *Element {
-	return e.Add(NormalTag("svg"))
-}
-
 // Table creates a <table> element and adds it to the current element.
+// Tabular data.
 func (e *Element) Table() *Element {
 	return e.Add(NormalTag("table"))
 }
 
 // Tbody creates a <tbody> element and adds it to the current element.
+// A group of body rows in a table.
 func (e *Element) Tbody() *Element {
 	return e.Add(NormalTag("tbody"))
 }
 
 // Td creates a <td> element and adds it to the current element.
+// A data cell in a table.
+//
+// Element-specific attributes: colspan, rowspan, headers.
 func (e *Element) Td() *Element {
 	return e.Add(NormalTag("td"))
 }
 
 // Template creates a <template> element and adds it to the current element.
+// Inert content cloned by scripts or attached as a declarative shadow root.
+//
+// Element-specific attributes: shadowrootmode, shadowrootdelegatesfocus,
+// shadowrootclonable.
 func (e *Element) Template() *Element {
 	return e.Add(NormalTag("template"))
 }
 
 // Textarea creates a <textarea> element and adds it to the current element.
+// A multi-line plain text editing control.
+//
+// Element-specific attributes: autocomplete, cols, dirname, disabled, form,
+// maxlength, minlength, name, placeholder, readonly, required, rows, wrap.
 func (e *Element) Textarea() *Element {
 	return e.Add(NormalTag("textarea"))
 }
 
 // Tfoot creates a <tfoot> element and adds it to the current element.
+// A group of summary rows at the end of a table.
 func (e *Element) Tfoot() *Element {
 	return e.Add(NormalTag("tfoot"))
 }
 
 // Th creates a <th> element and adds it to the current element.
+// A header cell in a table.
+//
+// Element-specific attributes: colspan, rowspan, headers, scope, abbr.
 func (e *Element) Th() *Element {
 	return e.Add(NormalTag("th"))
 }
 
 // Thead creates a <thead> element and adds it to the current element.
+// A group of header rows in a table.
 func (e *Element) Thead() *Element {
 	return e.Add(NormalTag("thead"))
 }
 
 // Time creates a <time> element and adds it to the current element.
+// A specific period in time.
+//
+// Element-specific attributes: datetime.
 func (e *Element) Time() *Element {
 	return e.Add(NormalTag("time"))
 }
 
 // Title creates a <title> element and adds it to the current element.
+// The title of the document.
 func (e *Element) Title() *Element {
 	return e.Add(NormalTag("title"))
 }
 
 // Tr creates a <tr> element and adds it to the current element.
+// A row of cells in a table.
 func (e *Element) Tr() *Element {
 	return e.Add(NormalTag("tr"))
 }
 
 // Track creates a void <track> element and adds it to the current element.
-func (e *Element) Track() *Element {
+// A timed text track for audio or video.
+//
+// Element-specific attributes: default, kind, label, src, srclang.
+func (e *Element) Track() *VoidElement {
 	return e.AddVoid(VoidTag("track"))
 }
 
 // Tt creates a <tt> element and adds it to the current element.
+// Text in a monospaced font.
+//
+// Deprecated: Use Code, Kbd or Samp instead.
 func (e *Element) Tt() *Element {
 	return e.Add(NormalTag("tt"))
 }
 
 // U creates a <u> element and adds it to the current element.
+// Text with an unarticulated, non-textual annotation.
 func (e *Element) U() *Element {
 	return e.Add(NormalTag("u"))
 }
 
 // Ul creates a <ul> element and adds it to the current element.
+// An unordered list.
 func (e *Element) Ul() *Element {
 	return e.Add(NormalTag("ul"))
 }
 
 // Var creates a <var> element and adds it to the current element.
+// A variable in a mathematical expression or program.
 func (e *Element) Var() *Element {
 	return e.Add(NormalTag("var"))
 }
 
 // Video creates a <video> element and adds it to the current element.
+// Embedded video content.
+//
+// Element-specific attributes: src, crossorigin, poster, preload, autoplay,
+// playsinline, loop, muted, controls, width, height.
 func (e *Element) Video() *Element {
 	return e.Add(NormalTag("video"))
 }
 
 // Wbr creates a void <wbr> element and adds it to the current element.
-func (e *Element) Wbr() *Element {
+// A position where a line may be broken.
+func (e *Element) Wbr() *VoidElement {
 	return e.AddVoid(VoidTag("wbr"))
 }
 
 // Xmp creates a <xmp> element and adds it to the current element.
+// Preformatted text shown as-is.
+//
+// Deprecated: Use Pre instead.
 func (e *Element) Xmp() *Element {
 	return e.Add(NormalTag("xmp"))
 }
 
-// Script creates a <script> element and adds it to the current element.
-func (e *Element) Script() *Element {
-	return e.Add(NormalTag("script"))
+// Svg creates an SVG <svg> element and adds it to the current element.
+// An SVG image. Its children are built with the Svg-prefixed methods.
+func (e *Element) Svg() *Element {
+	return e.AddSvg(SvgTag("svg"))
+}
+
+// SvgA creates an SVG <a> element and adds it to the current element.
+// A hyperlink within an SVG image.
+func (e *Element) SvgA() *Element {
+	return e.AddSvg(SvgTag("a"))
+}
+
+// SvgCircle creates an SVG <circle> element and adds it to the current element.
+// A circle.
+func (e *Element) SvgCircle() *Element {
+	return e.AddSvg(SvgTag("circle"))
+}
+
+// SvgClipPath creates an SVG <clipPath> element and adds it to the current element.
+// A clipping path.
+func (e *Element) SvgClipPath() *Element {
+	return e.AddSvg(SvgTag("clipPath"))
+}
+
+// SvgDefs creates an SVG <defs> element and adds it to the current element.
+// Definitions referenced elsewhere in the image.
+func (e *Element) SvgDefs() *Element {
+	return e.AddSvg(SvgTag("defs"))
+}
+
+// SvgDesc creates an SVG <desc> element and adds it to the current element.
+// An accessible description.
+func (e *Element) SvgDesc() *Element {
+	return e.AddSvg(SvgTag("desc"))
+}
+
+// SvgEllipse creates an SVG <ellipse> element and adds it to the current element.
+// An ellipse.
+func (e *Element) SvgEllipse() *Element {
+	return e.AddSvg(SvgTag("ellipse"))
+}
+
+// SvgFeBlend creates an SVG <feBlend> element and adds it to the current element.
+// A filter primitive blending two inputs.
+func (e *Element) SvgFeBlend() *Element {
+	return e.AddSvg(SvgTag("feBlend"))
+}
+
+// SvgFeColorMatrix creates an SVG <feColorMatrix> element and adds it to the current element.
+// A filter primitive applying a color matrix.
+func (e *Element) SvgFeColorMatrix() *Element {
+	return e.AddSvg(SvgTag("feColorMatrix"))
+}
+
+// SvgFeComponentTransfer creates an SVG <feComponentTransfer> element and adds it to the current element.
+// A filter primitive remapping color components.
+func (e *Element) SvgFeComponentTransfer() *Element {
+	return e.AddSvg(SvgTag("feComponentTransfer"))
+}
+
+// SvgFeComposite creates an SVG <feComposite> element and adds it to the current element.
+// A filter primitive compositing two inputs.
+func (e *Element) SvgFeComposite() *Element {
+	return e.AddSvg(SvgTag("feComposite"))
+}
+
+// SvgFeFlood creates an SVG <feFlood> element and adds it to the current element.
+// A filter primitive filling its region with a color.
+func (e *Element) SvgFeFlood() *Element {
+	return e.AddSvg(SvgTag("feFlood"))
+}
+
+// SvgFeGaussianBlur creates an SVG <feGaussianBlur> element and adds it to the current element.
+// A filter primitive applying a Gaussian blur.
+func (e *Element) SvgFeGaussianBlur() *Element {
+	return e.AddSvg(SvgTag("feGaussianBlur"))
+}
+
+// SvgFeMerge creates an SVG <feMerge> element and adds it to the current element.
+// A filter primitive layering several inputs.
+func (e *Element) SvgFeMerge() *Element {
+	return e.AddSvg(SvgTag("feMerge"))
+}
+
+// SvgFeMergeNode creates an SVG <feMergeNode> element and adds it to the current element.
+// An input of feMerge.
+func (e *Element) SvgFeMergeNode() *Element {
+	return e.AddSvg(SvgTag("feMergeNode"))
+}
+
+// SvgFeOffset creates an SVG <feOffset> element and adds it to the current element.
+// A filter primitive offsetting its input.
+func (e *Element) SvgFeOffset() *Element {
+	return e.AddSvg(SvgTag("feOffset"))
+}
+
+// SvgFilter creates an SVG <filter> element and adds it to the current element.
+// A filter effect.
+func (e *Element) SvgFilter() *Element {
+	return e.AddSvg(SvgTag("filter"))
+}
+
+// SvgForeignObject creates an SVG <foreignObject> element and adds it to the current element.
+// A container for content from another namespace, usually HTML.
+func (e *Element) SvgForeignObject() *Element {
+	return e.AddSvg(SvgTag("foreignObject"))
+}
+
+// SvgG creates an SVG <g> element and adds it to the current element.
+// A group of shapes.
+func (e *Element) SvgG() *Element {
+	return e.AddSvg(SvgTag("g"))
+}
+
+// SvgImage creates an SVG <image> element and adds it to the current element.
+// A raster or SVG image.
+func (e *Element) SvgImage() *Element {
+	return e.AddSvg(SvgTag("image"))
+}
+
+// SvgLine creates an SVG <line> element and adds it to the current element.
+// A straight line.
+func (e *Element) SvgLine() *Element {
+	return e.AddSvg(SvgTag("line"))
+}
+
+// SvgLinearGradient creates an SVG <linearGradient> element and adds it to the current element.
+// A linear gradient paint server.
+func (e *Element) SvgLinearGradient() *Element {
+	return e.AddSvg(SvgTag("linearGradient"))
+}
+
+// SvgMarker creates an SVG <marker> element and adds it to the current element.
+// A marker drawn at path vertices.
+func (e *Element) SvgMarker() *Element {
+	return e.AddSvg(SvgTag("marker"))
+}
+
+// SvgMask creates an SVG <mask> element and adds it to the current element.
+// An alpha mask.
+func (e *Element) SvgMask() *Element {
+	return e.AddSvg(SvgTag("mask"))
+}
+
+// SvgMetadata creates an SVG <metadata> element and adds it to the current element.
+// Metadata about the image.
+func (e *Element) SvgMetadata() *Element {
+	return e.AddSvg(SvgTag("metadata"))
+}
+
+// SvgPath creates an SVG <path> element and adds it to the current element.
+// A generic shape described by path data.
+func (e *Element) SvgPath() *Element {
+	return e.AddSvg(SvgTag("path"))
+}
+
+// SvgPattern creates an SVG <pattern> element and adds it to the current element.
+// A pattern paint server.
+func (e *Element) SvgPattern() *Element {
+	return e.AddSvg(SvgTag("pattern"))
+}
+
+// SvgPolygon creates an SVG <polygon> element and adds it to the current element.
+// A closed shape of straight line segments.
+func (e *Element) SvgPolygon() *Element {
+	return e.AddSvg(SvgTag("polygon"))
+}
+
+// SvgPolyline creates an SVG <polyline> element and adds it to the current element.
+// An open shape of straight line segments.
+func (e *Element) SvgPolyline() *Element {
+	return e.AddSvg(SvgTag("polyline"))
+}
+
+// SvgRadialGradient creates an SVG <radialGradient> element and adds it to the current element.
+// A radial gradient paint server.
+func (e *Element) SvgRadialGradient() *Element {
+	return e.AddSvg(SvgTag("radialGradient"))
+}
+
+// SvgRect creates an SVG <rect> element and adds it to the current element.
+// A rectangle.
+func (e *Element) SvgRect() *Element {
+	return e.AddSvg(SvgTag("rect"))
+}
+
+// SvgStop creates an SVG <stop> element and adds it to the current element.
+// A color stop of a gradient.
+func (e *Element) SvgStop() *Element {
+	return e.AddSvg(SvgTag("stop"))
+}
+
+// SvgSwitch creates an SVG <switch> element and adds it to the current element.
+// Renders the first child whose conditions match.
+func (e *Element) SvgSwitch() *Element {
+	return e.AddSvg(SvgTag("switch"))
+}
+
+// SvgSymbol creates an SVG <symbol> element and adds it to the current element.
+// A graphical template instantiated by use.
+func (e *Element) SvgSymbol() *Element {
+	return e.AddSvg(SvgTag("symbol"))
+}
+
+// SvgText creates an SVG <text> element and adds it to the current element.
+// A run of text.
+func (e *Element) SvgText() *Element {
+	return e.AddSvg(SvgTag("text"))
+}
+
+// SvgTextPath creates an SVG <textPath> element and adds it to the current element.
+// Text rendered along a path.
+func (e *Element) SvgTextPath() *Element {
+	return e.AddSvg(SvgTag("textPath"))
+}
+
+// SvgTitle creates an SVG <title> element and adds it to the current element.
+// An accessible name for the image or element.
+func (e *Element) SvgTitle() *Element {
+	return e.AddSvg(SvgTag("title"))
+}
+
+// SvgTspan creates an SVG <tspan> element and adds it to the current element.
+// A sub-run of text.
+func (e *Element) SvgTspan() *Element {
+	return e.AddSvg(SvgTag("tspan"))
+}
+
+// SvgUse creates an SVG <use> element and adds it to the current element.
+// A copy of another element referenced by href.
+func (e *Element) SvgUse() *Element {
+	return e.AddSvg(SvgTag("use"))
+}
+
+// SvgView creates an SVG <view> element and adds it to the current element.
+// A named view of the image.
+func (e *Element) SvgView() *Element {
+	return e.AddSvg(SvgTag("view"))
+}
+
+// Math creates a MathML <math> element and adds it to the current element.
+// A MathML formula. Its children are built with methods such as Mi, Mo and
+// Mfrac.
+func (e *Element) Math() *Element {
+	return e.AddMath(MathTag("math"))
+}
+
+// Annotation creates a MathML <annotation> element and adds it to the current element.
+// An annotation in a non-XML format, such as TeX.
+func (e *Element) Annotation() *Element {
+	return e.AddMath(MathTag("annotation"))
+}
+
+// AnnotationXml creates a MathML <annotation-xml> element and adds it to the current element.
+// An annotation in an XML format, such as HTML or Content MathML.
+func (e *Element) AnnotationXml() *Element {
+	return e.AddMath(MathTag("annotation-xml"))
+}
+
+// Maction creates a MathML <maction> element and adds it to the current element.
+// A bind of actions to a sub-expression.
+func (e *Element) Maction() *Element {
+	return e.AddMath(MathTag("maction"))
+}
+
+// Merror creates a MathML <merror> element and adds it to the current element.
+// An error message.
+func (e *Element) Merror() *Element {
+	return e.AddMath(MathTag("merror"))
+}
+
+// Mfrac creates a MathML <mfrac> element and adds it to the current element.
+// A fraction.
+func (e *Element) Mfrac() *Element {
+	return e.AddMath(MathTag("mfrac"))
+}
+
+// Mi creates a MathML <mi> element and adds it to the current element.
+// An identifier.
+func (e *Element) Mi() *Element {
+	return e.AddMath(MathTag("mi"))
+}
+
+// Mmultiscripts creates a MathML <mmultiscripts> element and adds it to the current element.
+// Prescripts and tensor indices.
+func (e *Element) Mmultiscripts() *Element {
+	return e.AddMath(MathTag("mmultiscripts"))
+}
+
+// Mn creates a MathML <mn> element and adds it to the current element.
+// A numeric literal.
+func (e *Element) Mn() *Element {
+	return e.AddMath(MathTag("mn"))
+}
+
+// Mo creates a MathML <mo> element and adds it to the current element.
+// An operator.
+func (e *Element) Mo() *Element {
+	return e.AddMath(MathTag("mo"))
+}
+
+// Mover creates a MathML <mover> element and adds it to the current element.
+// An overscript.
+func (e *Element) Mover() *Element {
+	return e.AddMath(MathTag("mover"))
+}
+
+// Mpadded creates a MathML <mpadded> element and adds it to the current element.
+// Extra padding around its content.
+func (e *Element) Mpadded() *Element {
+	return e.AddMath(MathTag("mpadded"))
+}
+
+// Mphantom creates a MathML <mphantom> element and adds it to the current element.
+// Invisible content that still takes up space.
+func (e *Element) Mphantom() *Element {
+	return e.AddMath(MathTag("mphantom"))
+}
+
+// Mprescripts creates a MathML <mprescripts> element and adds it to the current element.
+// The separator before prescripts in mmultiscripts.
+func (e *Element) Mprescripts() *Element {
+	return e.AddMath(MathTag("mprescripts"))
+}
+
+// Mroot creates a MathML <mroot> element and adds it to the current element.
+// A radical with an index.
+func (e *Element) Mroot() *Element {
+	return e.AddMath(MathTag("mroot"))
+}
+
+// Mrow creates a MathML <mrow> element and adds it to the current element.
+// A horizontal group of sub-expressions.
+func (e *Element) Mrow() *Element {
+	return e.AddMath(MathTag("mrow"))
+}
+
+// Ms creates a MathML <ms> element and adds it to the current element.
+// A string literal.
+func (e *Element) Ms() *Element {
+	return e.AddMath(MathTag("ms"))
+}
+
+// Mspace creates a MathML <mspace> element and adds it to the current element.
+// Blank space.
+func (e *Element) Mspace() *Element {
+	return e.AddMath(MathTag("mspace"))
+}
+
+// Msqrt creates a MathML <msqrt> element and adds it to the current element.
+// A square root.
+func (e *Element) Msqrt() *Element {
+	return e.AddMath(MathTag("msqrt"))
+}
+
+// Mstyle creates a MathML <mstyle> element and adds it to the current element.
+// Style changes for its children.
+func (e *Element) Mstyle() *Element {
+	return e.AddMath(MathTag("mstyle"))
+}
+
+// Msub creates a MathML <msub> element and adds it to the current element.
+// A subscript.
+func (e *Element) Msub() *Element {
+	return e.AddMath(MathTag("msub"))
+}
+
+// Msubsup creates a MathML <msubsup> element and adds it to the current element.
+// A subscript and superscript pair.
+func (e *Element) Msubsup() *Element {
+	return e.AddMath(MathTag("msubsup"))
+}
+
+// Msup creates a MathML <msup> element and adds it to the current element.
+// A superscript.
+func (e *Element) Msup() *Element {
+	return e.AddMath(MathTag("msup"))
+}
+
+// Mtable creates a MathML <mtable> element and adds it to the current element.
+// A table or matrix.
+func (e *Element) Mtable() *Element {
+	return e.AddMath(MathTag("mtable"))
+}
+
+// Mtd creates a MathML <mtd> element and adds it to the current element.
+// A cell in a table or matrix.
+func (e *Element) Mtd() *Element {
+	return e.AddMath(MathTag("mtd"))
+}
+
+// Mtext creates a MathML <mtext> element and adds it to the current element.
+// Arbitrary text.
+func (e *Element) Mtext() *Element {
+	return e.AddMath(MathTag("mtext"))
+}
+
+// Mtr creates a MathML <mtr> element and adds it to the current element.
+// A row in a table or matrix.
+func (e *Element) Mtr() *Element {
+	return e.AddMath(MathTag("mtr"))
+}
+
+// Munder creates a MathML <munder> element and adds it to the current element.
+// An underscript.
+func (e *Element) Munder() *Element {
+	return e.AddMath(MathTag("munder"))
+}
+
+// Munderover creates a MathML <munderover> element and adds it to the current element.
+// An underscript and overscript pair.
+func (e *Element) Munderover() *Element {
+	return e.AddMath(MathTag("munderover"))
+}
+
+// Semantics creates a MathML <semantics> element and adds it to the current element.
+// An expression together with its annotations.
+func (e *Element) Semantics() *Element {
+	return e.AddMath(MathTag("semantics"))
+}
+
+// elementCategories lists the content categories of each element, keyed by
+// tag name.
+var elementCategories = map[string][]string{
+	"a":           {"flow", "phrasing", "interactive"},
+	"abbr":        {"flow", "phrasing"},
+	"acronym":     {"flow", "phrasing"},
+	"address":     {"flow"},
+	"area":        {"flow", "phrasing"},
+	"article":     {"flow", "sectioning"},
+	"aside":       {"flow", "sectioning"},
+	"audio":       {"flow", "phrasing", "embedded", "interactive"},
+	"b":           {"flow", "phrasing"},
+	"base":        {"metadata"},
+	"bdi":         {"flow", "phrasing"},
+	"bdo":         {"flow", "phrasing"},
+	"big":         {"flow", "phrasing"},
+	"blockquote":  {"flow"},
+	"br":          {"flow", "phrasing"},
+	"button":      {"flow", "phrasing", "interactive"},
+	"canvas":      {"flow", "phrasing", "embedded"},
+	"center":      {"flow"},
+	"cite":        {"flow", "phrasing"},
+	"code":        {"flow", "phrasing"},
+	"data":        {"flow", "phrasing"},
+	"datalist":    {"flow", "phrasing"},
+	"del":         {"flow", "phrasing"},
+	"details":     {"flow", "interactive"},
+	"dfn":         {"flow", "phrasing"},
+	"dialog":      {"flow"},
+	"dir":         {"flow"},
+	"div":         {"flow"},
+	"dl":          {"flow"},
+	"em":          {"flow", "phrasing"},
+	"embed":       {"flow", "phrasing", "embedded", "interactive"},
+	"fencedframe": {"flow", "phrasing", "embedded", "interactive"},
+	"fieldset":    {"flow"},
+	"figure":      {"flow"},
+	"font":        {"flow", "phrasing"},
+	"footer":      {"flow"},
+	"form":        {"flow"},
+	"h1":          {"flow", "heading"},
+	"h2":          {"flow", "heading"},
+	"h3":          {"flow", "heading"},
+	"h4":          {"flow", "heading"},
+	"h5":          {"flow", "heading"},
+	"h6":          {"flow", "heading"},
+	"header":      {"flow"},
+	"hgroup":      {"flow", "heading"},
+	"hr":          {"flow"},
+	"i":           {"flow", "phrasing"},
+	"iframe":      {"flow", "phrasing", "embedded", "interactive"},
+	"img":         {"flow", "phrasing", "embedded"},
+	"input":       {"flow", "phrasing", "interactive"},
+	"ins":         {"flow", "phrasing"},
+	"kbd":         {"flow", "phrasing"},
+	"label":       {"flow", "phrasing", "interactive"},
+	"link":        {"metadata"},
+	"main":        {"flow"},
+	"map":         {"flow", "phrasing"},
+	"mark":        {"flow", "phrasing"},
+	"marquee":     {"flow", "phrasing"},
+	"math":        {"flow", "phrasing"},
+	"menu":        {"flow"},
+	"meta":        {"metadata"},
+	"meter":       {"flow", "phrasing"},
+	"nav":         {"flow", "sectioning"},
+	"nobr":        {"flow", "phrasing"},
+	"noscript":    {"metadata", "flow", "phrasing"},
+	"object":      {"flow", "phrasing", "embedded", "interactive"},
+	"ol":          {"flow"},
+	"output":      {"flow", "phrasing"},
+	"p":           {"flow"},
+	"picture":     {"flow", "phrasing", "embedded"},
+	"plaintext":   {"flow"},
+	"portal":      {"flow", "phrasing", "embedded"},
+	"pre":         {"flow"},
+	"progress":    {"flow", "phrasing"},
+	"q":           {"flow", "phrasing"},
+	"ruby":        {"flow", "phrasing"},
+	"s":           {"flow", "phrasing"},
+	"samp":        {"flow", "phrasing"},
+	"script":      {"metadata", "flow", "phrasing"},
+	"search":      {"flow"},
+	"section":     {"flow", "sectioning"},
+	"select":      {"flow", "phrasing", "interactive"},
+	"slot":        {"flow", "phrasing"},
+	"small":       {"flow", "phrasing"},
+	"span":        {"flow", "phrasing"},
+	"strike":      {"flow", "phrasing"},
+	"strong":      {"flow", "phrasing"},
+	"style":       {"metadata"},
+	"sub":         {"flow", "phrasing"},
+	"sup":         {"flow", "phrasing"},
+	"svg":         {"flow", "phrasing", "embedded"},
+	"table":       {"flow"},
+	"template":    {"metadata", "flow", "phrasing"},
+	"textarea":    {"flow", "phrasing", "interactive"},
+	"time":        {"flow", "phrasing"},
+	"title":       {"metadata"},
+	"tt":          {"flow", "phrasing"},
+	"u":           {"flow", "phrasing"},
+	"ul":          {"flow"},
+	"var":         {"flow", "phrasing"},
+	"video":       {"flow", "phrasing", "embedded", "interactive"},
+	"wbr":         {"flow", "phrasing"},
+	"xmp":         {"flow"},
+}
+
+// elementAttributes lists the attributes specific to each HTML element,
+// keyed by tag name.
+var elementAttributes = map[string][]string{
+	"a":           {"href", "target", "download", "ping", "rel", "hreflang", "type", "referrerpolicy"},
+	"area":        {"alt", "coords", "shape", "href", "target", "download", "ping", "rel", "referrerpolicy"},
+	"audio":       {"src", "crossorigin", "preload", "autoplay", "loop", "muted", "controls"},
+	"base":        {"href", "target"},
+	"blockquote":  {"cite"},
+	"button":      {"disabled", "form", "formaction", "formenctype", "formmethod", "formnovalidate", "formtarget", "name", "popovertarget", "popovertargetaction", "type", "value"},
+	"canvas":      {"width", "height"},
+	"col":         {"span"},
+	"colgroup":    {"span"},
+	"data":        {"value"},
+	"del":         {"cite", "datetime"},
+	"details":     {"open", "name"},
+	"dialog":      {"open"},
+	"embed":       {"src", "type", "width", "height"},
+	"fencedframe": {"allow", "width", "height"},
+	"fieldset":    {"disabled", "form", "name"},
+	"font":        {"color", "face", "size"},
+	"form":        {"accept-charset", "action", "autocomplete", "enctype", "method", "name", "novalidate", "target", "rel"},
+	"frame":       {"src", "name"},
+	"frameset":    {"cols", "rows"},
+	"iframe":      {"src", "srcdoc", "name", "sandbox", "allow", "allowfullscreen", "width", "height", "referrerpolicy", "loading"},
+	"img":         {"alt", "src", "srcset", "sizes", "crossorigin", "usemap", "ismap", "width", "height", "referrerpolicy", "decoding", "loading", "fetchpriority"},
+	"input":       {"accept", "alt", "autocomplete", "checked", "dirname", "disabled", "form", "formaction", "formenctype", "formmethod", "formnovalidate", "formtarget", "height", "list", "max", "maxlength", "min", "minlength", "multiple", "name", "pattern", "placeholder", "readonly", "required", "size", "src", "step", "type", "value", "width"},
+	"ins":         {"cite", "datetime"},
+	"label":       {"for"},
+	"li":          {"value"},
+	"link":        {"href", "crossorigin", "rel", "media", "integrity", "hreflang", "type", "referrerpolicy", "sizes", "as", "blocking", "color", "disabled", "fetchpriority"},
+	"map":         {"name"},
+	"marquee":     {"behavior", "direction", "loop", "scrollamount", "scrolldelay"},
+	"meta":        {"name", "http-equiv", "content", "charset", "media"},
+	"meter":       {"value", "min", "max", "low", "high", "optimum"},
+	"object":      {"data", "type", "name", "form", "width", "height"},
+	"ol":          {"reversed", "start", "type"},
+	"optgroup":    {"disabled", "label"},
+	"option":      {"disabled", "label", "selected", "value"},
+	"output":      {"for", "form", "name"},
+	"param":       {"name", "value"},
+	"portal":      {"src", "referrerpolicy"},
+	"progress":    {"value", "max"},
+	"q":           {"cite"},
+	"script":      {"src", "type", "nomodule", "async", "defer", "crossorigin", "integrity", "referrerpolicy", "blocking", "fetchpriority"},
+	"select":      {"autocomplete", "disabled", "form", "multiple", "name", "required", "size"},
+	"slot":        {"name"},
+	"source":      {"type", "media", "src", "srcset", "sizes", "width", "height"},
+	"style":       {"media", "blocking"},
+	"td":          {"colspan", "rowspan", "headers"},
+	"template":    {"shadowrootmode", "shadowrootdelegatesfocus", "shadowrootclonable"},
+	"textarea":    {"autocomplete", "cols", "dirname", "disabled", "form", "maxlength", "minlength", "name", "placeholder", "readonly", "required", "rows", "wrap"},
+	"th":          {"colspan", "rowspan", "headers", "scope", "abbr"},
+	"time":        {"datetime"},
+	"track":       {"default", "kind", "label", "src", "srclang"},
+	"video":       {"src", "crossorigin", "poster", "preload", "autoplay", "playsinline", "loop", "muted", "controls", "width", "height"},
 }
@@ -0,0 +1,324 @@
+package htmlsimple
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// TailwindMerger returns a ClassMerger for Tailwind CSS. Of two utilities
+// that set the same property under the same variants the later one wins,
+// and a later shorthand such as p-4 removes earlier px-2 and pt-1. Classes
+// it does not recognize are kept as they are.
+//
+// The returned merger can be adapted, for example to add the utilities of
+// a plugin:
+//
+//	m := htmlsimple.TailwindMerger()
+//	group := m.Group
+//	m.Group = func(utility string) string {
+//		if strings.HasPrefix(utility, "btn-") {
+//			return "btn"
+//		}
+//		return group(utility)
+//	}
+func TailwindMerger() *UtilityMerger {
+	return &UtilityMerger{Group: tailwindGroup, Conflicts: tailwindConflicts}
+}
+
+// tailwindGroup returns the group of a Tailwind utility.
+func tailwindGroup(utility string) string {
+	// Negative values, such as -mt-2, belong to the same group.
+	utility = strings.TrimPrefix(utility, "-")
+	if group, ok := tailwindExact[utility]; ok {
+		return group
+	}
+	for _, rule := range tailwindRules {
+		var value string
+		switch {
+		case utility == rule.prefix:
+		case strings.HasPrefix(utility, rule.prefix+"-"):
+			value = utility[len(rule.prefix)+1:]
+		default:
+			continue
+		}
+		if group := rule.group(value); group != "" {
+			return group
+		}
+	}
+	return ""
+}
+
+// tailwindRule maps the utilities starting with prefix to a group, decided
+// by group from the value after the prefix.
+type tailwindRule struct {
+	prefix string
+	group  func(value string) string
+}
+
+// always returns a group function that puts every value in group.
+func always(group string) func(string) string {
+	return func(string) string { return group }
+}
+
+// byValue returns a group function that puts the listed values in their
+// group, lengths in length if set, and everything else in fallback.
+func byValue(values map[string]string, length, fallback string) func(string) string {
+	return func(value string) string {
+		if group, ok := values[value]; ok {
+			return group
+		}
+		if length != "" && isTailwindLength(value) {
+			return length
+		}
+		return fallback
+	}
+}
+
+// oneGroup returns a map putting every value in group, for byValue.
+func oneGroup(group string, values ...string) map[string]string {
+	m := make(map[string]string, len(values))
+	for _, v := range values {
+		m[v] = group
+	}
+	return m
+}
+
+// merged combines maps for byValue.
+func merged(maps ...map[string]string) map[string]string {
+	m := make(map[string]string)
+	for _, part := range maps {
+		for k, v := range part {
+			m[k] = v
+		}
+	}
+	return m
+}
+
+var arbitraryLength = regexp.MustCompile(`^[\[(](length:)?-?\d*\.?\d+(px|r?em|%|vh|vw|[sld]vh|vmin|vmax|ch|ex|pt|cm|mm|in)?[\])]$`)
+
+// isTailwindLength reports whether value is a width such as "", "2", "1.5"
+// or "[3px]", as opposed to a color or keyword.
+func isTailwindLength(value string) bool {
+	if value == "" || arbitraryLength.MatchString(value) {
+		return true
+	}
+	for _, c := range value {
+		if (c < '0' || c > '9') && c != '.' && c != '/' {
+			return false
+		}
+	}
+	return true
+}
+
+// isTailwindSize reports whether value is a font size such as "lg" or
+// "[14px]".
+func isTailwindSize(value string) bool {
+	switch value {
+	case "xs", "sm", "base", "md", "lg", "xl":
+		return true
+	}
+	if strings.HasSuffix(value, "xl") && strings.Trim(value[:len(value)-2], "0123456789") == "" {
+		return true
+	}
+	return arbitraryLength.MatchString(value)
+}
+
+// tailwindExact holds utilities that are a complete class on their own.
+var tailwindExact = merged(
+	oneGroup("display", "block", "inline-block", "inline", "flex", "inline-flex",
+		"table", "inline-table", "table-caption", "table-cell", "table-column",
+		"table-column-group", "table-footer-group", "table-header-group",
+		"table-row-group", "table-row", "flow-root", "grid", "inline-grid",
+		"contents", "list-item", "hidden"),
+	oneGroup("position", "static", "fixed", "absolute", "relative", "sticky"),
+	oneGroup("visibility", "visible", "invisible", "collapse"),
+	oneGroup("isolation", "isolate", "isolation-auto"),
+	oneGroup("font-style", "italic", "not-italic"),
+	oneGroup("font-smoothing", "antialiased", "subpixel-antialiased"),
+	oneGroup("text-decoration-line", "underline", "overline", "line-through", "no-underline"),
+	oneGroup("text-transform", "uppercase", "lowercase", "capitalize", "normal-case"),
+	oneGroup("text-overflow", "truncate"),
+	oneGroup("flex-direction", "flex-row", "flex-row-reverse", "flex-col", "flex-col-reverse"),
+	oneGroup("flex-wrap", "flex-wrap", "flex-wrap-reverse", "flex-nowrap"),
+	oneGroup("sr", "sr-only", "not-sr-only"),
+)
+
+// tailwindRules are tried in order, so a longer prefix must come before a
+// shorter one it starts with, such as rounded-t before rounded.
+var tailwindRules = []tailwindRule{
+	// Spacing and sizing.
+	{"p", always("p")}, {"px", always("px")}, {"py", always("py")},
+	{"pt", always("pt")}, {"pr", always("pr")}, {"pb", always("pb")},
+	{"pl", always("pl")}, {"ps", always("ps")}, {"pe", always("pe")},
+	{"m", always("m")}, {"mx", always("mx")}, {"my", always("my")},
+	{"mt", always("mt")}, {"mr", always("mr")}, {"mb", always("mb")},
+	{"ml", always("ml")}, {"ms", always("ms")}, {"me", always("me")},
+	{"space-x", always("space-x")}, {"space-y", always("space-y")},
+	{"gap-x", always("gap-x")}, {"gap-y", always("gap-y")}, {"gap", always("gap")},
+	{"min-w", always("min-w")}, {"min-h", always("min-h")},
+	{"max-w", always("max-w")}, {"max-h", always("max-h")},
+	{"w", always("w")}, {"h", always("h")}, {"size", always("size")},
+
+	// Layout.
+	{"inset-x", always("inset-x")}, {"inset-y", always("inset-y")}, {"inset", always("inset")},
+	{"top", always("top")}, {"right", always("right")}, {"bottom", always("bottom")},
+	{"left", always("left")}, {"start", always("start")}, {"end", always("end")},
+	{"z", always("z")}, {"order", always("order")}, {"float", always("float")},
+	{"clear", always("clear")}, {"box", always("box-sizing")}, {"aspect", always("aspect")},
+	{"columns", always("columns")}, {"object", byValue(
+		oneGroup("object-fit", "contain", "cover", "fill", "none", "scale-down"), "", "object-position")},
+	{"overflow-x", always("overflow-x")}, {"overflow-y", always("overflow-y")},
+	{"overflow", always("overflow")},
+	{"overscroll-x", always("overscroll-x")}, {"overscroll-y", always("overscroll-y")},
+	{"overscroll", always("overscroll")},
+
+	// Flexbox and grid.
+	{"basis", always("basis")}, {"grow", always("grow")}, {"shrink", always("shrink")},
+	{"flex", always("flex")},
+	{"grid-cols", always("grid-cols")}, {"grid-rows", always("grid-rows")},
+	{"grid-flow", always("grid-flow")}, {"auto-cols", always("auto-cols")},
+	{"auto-rows", always("auto-rows")},
+	{"col-span", always("col-span")}, {"col-start", always("col-start")}, {"col-end", always("col-end")},
+	{"row-span", always("row-span")}, {"row-start", always("row-start")}, {"row-end", always("row-end")},
+	{"justify-items", always("justify-items")}, {"justify-self", always("justify-self")},
+	{"justify", always("justify-content")},
+	{"items", always("align-items")}, {"self", always("align-self")},
+	{"place-content", always("place-content")}, {"place-items", always("place-items")},
+	{"place-self", always("place-self")},
+	{"content", byValue(oneGroup("align-content", "normal", "center", "start", "end",
+		"between", "around", "evenly", "baseline", "stretch"), "", "content")},
+
+	// Typography.
+	{"text", func(value string) string {
+		switch {
+		case isTailwindSize(value):
+			return "font-size"
+		case slices.Contains([]string{"left", "center", "right", "justify", "start", "end"}, value):
+			return "text-align"
+		case value == "ellipsis" || value == "clip":
+			return "text-overflow"
+		case slices.Contains([]string{"wrap", "nowrap", "balance", "pretty"}, value):
+			return "text-wrap"
+		}
+		return "text-color"
+	}},
+	{"font", byValue(oneGroup("font-weight", "thin", "extralight", "light", "normal",
+		"medium", "semibold", "bold", "extrabold", "black"), "font-weight", "font-family")},
+	{"leading", always("leading")}, {"tracking", always("tracking")},
+	{"indent", always("indent")}, {"line-clamp", always("line-clamp")},
+	{"whitespace", always("whitespace")}, {"align", always("vertical-align")},
+	{"break-before", always("break-before")}, {"break-after", always("break-after")},
+	{"break-inside", always("break-inside")}, {"break", always("word-break")},
+	{"list", byValue(oneGroup("list-position", "inside", "outside"), "", "list-style-type")},
+	{"decoration", byValue(oneGroup("decoration-style", "solid", "double", "dotted", "dashed", "wavy"),
+		"decoration-thickness", "decoration-color")},
+	{"underline-offset", always("underline-offset")},
+
+	// Backgrounds and gradients.
+	{"bg-clip", always("bg-clip")}, {"bg-origin", always("bg-origin")},
+	{"bg", func(value string) string {
+		switch value {
+		case "fixed", "local", "scroll":
+			return "bg-attachment"
+		case "bottom", "center", "left", "left-bottom", "left-top", "right",
+			"right-bottom", "right-top", "top":
+			return "bg-position"
+		case "repeat", "no-repeat", "repeat-x", "repeat-y", "repeat-round", "repeat-space":
+			return "bg-repeat"
+		case "auto", "cover", "contain":
+			return "bg-size"
+		case "none":
+			return "bg-image"
+		}
+		for _, prefix := range []string{"gradient-", "linear-", "radial", "conic", "[url(", "(url("} {
+			if strings.HasPrefix(value, prefix) {
+				return "bg-image"
+			}
+		}
+		return "bg-color"
+	}},
+	{"from", always("gradient-from")}, {"via", always("gradient-via")}, {"to", always("gradient-to")},
+
+	// Borders, rings and outlines.
+	{"rounded-ss", always("rounded-ss")}, {"rounded-se", always("rounded-se")},
+	{"rounded-ee", always("rounded-ee")}, {"rounded-es", always("rounded-es")},
+	{"rounded-tl", always("rounded-tl")}, {"rounded-tr", always("rounded-tr")},
+	{"rounded-br", always("rounded-br")}, {"rounded-bl", always("rounded-bl")},
+	{"rounded-s", always("rounded-s")}, {"rounded-e", always("rounded-e")},
+	{"rounded-t", always("rounded-t")}, {"rounded-r", always("rounded-r")},
+	{"rounded-b", always("rounded-b")}, {"rounded-l", always("rounded-l")},
+	{"rounded", always("rounded")},
+	{"border-x", byValue(nil, "border-w-x", "border-color-x")},
+	{"border-y", byValue(nil, "border-w-y", "border-color-y")},
+	{"border-s", byValue(nil, "border-w-s", "border-color-s")},
+	{"border-e", byValue(nil, "border-w-e", "border-color-e")},
+	{"border-t", byValue(nil, "border-w-t", "border-color-t")},
+	{"border-r", byValue(nil, "border-w-r", "border-color-r")},
+	{"border-b", byValue(nil, "border-w-b", "border-color-b")},
+	{"border-l", byValue(nil, "border-w-l", "border-color-l")},
+	{"border-spacing", always("border-spacing")},
+	{"border", byValue(merged(
+		oneGroup("border-style", "solid", "dashed", "dotted", "double", "hidden", "none"),
+		oneGroup("border-collapse", "collapse", "separate"),
+	), "border-w", "border-color")},
+	{"ring-offset", byValue(nil, "ring-offset-w", "ring-offset-color")},
+	{"ring", byValue(oneGroup("ring-inset", "inset"), "ring-w", "ring-color")},
+	{"outline-offset", always("outline-offset")},
+	{"outline", byValue(oneGroup("outline-style", "none", "dashed", "dotted", "double"),
+		"outline-w", "outline-color")},
+
+	// Effects, filters and transforms.
+	{"shadow", byValue(oneGroup("shadow", "sm", "md", "lg", "xl", "2xl", "inner", "none"),
+		"shadow", "shadow-color")},
+	{"opacity", always("opacity")}, {"mix-blend", always("mix-blend")},
+	{"blur", always("blur")}, {"brightness", always("brightness")},
+	{"contrast", always("contrast")}, {"grayscale", always("grayscale")},
+	{"invert", always("invert")}, {"saturate", always("saturate")},
+	{"sepia", always("sepia")}, {"drop-shadow", always("drop-shadow")},
+	{"scale-x", always("scale-x")}, {"scale-y", always("scale-y")}, {"scale", always("scale")},
+	{"rotate", always("rotate")}, {"translate-x", always("translate-x")},
+	{"translate-y", always("translate-y")}, {"skew-x", always("skew-x")},
+	{"skew-y", always("skew-y")}, {"origin", always("origin")},
+	{"transition", always("transition")}, {"duration", always("duration")},
+	{"ease", always("ease")}, {"delay", always("delay")}, {"animate", always("animate")},
+
+	// Interactivity and SVG.
+	{"cursor", always("cursor")}, {"select", always("select")},
+	{"pointer-events", always("pointer-events")}, {"resize", always("resize")},
+	{"appearance", always("appearance")}, {"accent", always("accent")},
+	{"caret", always("caret")}, {"will-change", always("will-change")},
+	{"fill", always("fill")}, {"stroke", byValue(nil, "stroke-w", "stroke")},
+}
+
+// tailwindConflicts lists the groups a shorthand overrides.
+var tailwindConflicts = map[string][]string{
+	"p":              {"px", "py", "pt", "pr", "pb", "pl", "ps", "pe"},
+	"px":             {"pr", "pl"},
+	"py":             {"pt", "pb"},
+	"m":              {"mx", "my", "mt", "mr", "mb", "ml", "ms", "me"},
+	"mx":             {"mr", "ml"},
+	"my":             {"mt", "mb"},
+	"gap":            {"gap-x", "gap-y"},
+	"size":           {"w", "h"},
+	"inset":          {"inset-x", "inset-y", "top", "right", "bottom", "left", "start", "end"},
+	"inset-x":        {"right", "left"},
+	"inset-y":        {"top", "bottom"},
+	"overflow":       {"overflow-x", "overflow-y"},
+	"overscroll":     {"overscroll-x", "overscroll-y"},
+	"scale":          {"scale-x", "scale-y"},
+	"font-size":      {"leading"},
+	"rounded":        {"rounded-s", "rounded-e", "rounded-t", "rounded-r", "rounded-b", "rounded-l", "rounded-ss", "rounded-se", "rounded-ee", "rounded-es", "rounded-tl", "rounded-tr", "rounded-br", "rounded-bl"},
+	"rounded-s":      {"rounded-ss", "rounded-es"},
+	"rounded-e":      {"rounded-se", "rounded-ee"},
+	"rounded-t":      {"rounded-tl", "rounded-tr"},
+	"rounded-r":      {"rounded-tr", "rounded-br"},
+	"rounded-b":      {"rounded-br", "rounded-bl"},
+	"rounded-l":      {"rounded-tl", "rounded-bl"},
+	"border-w":       {"border-w-x", "border-w-y", "border-w-s", "border-w-e", "border-w-t", "border-w-r", "border-w-b", "border-w-l"},
+	"border-w-x":     {"border-w-r", "border-w-l"},
+	"border-w-y":     {"border-w-t", "border-w-b"},
+	"border-color":   {"border-color-x", "border-color-y", "border-color-s", "border-color-e", "border-color-t", "border-color-r", "border-color-b", "border-color-l"},
+	"border-color-x": {"border-color-r", "border-color-l"},
+	"border-color-y": {"border-color-t", "border-color-b"},
+}
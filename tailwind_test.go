@@ -0,0 +1,99 @@
+package htmlsimple
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestTailwindGroup(t *testing.T) {
+	tests := []struct {
+		utility string
+		want    string
+	}{
+		{"flex", "display"},
+		{"hidden", "display"},
+		{"p-4", "p"},
+		{"px-2", "px"},
+		{"-mt-2", "mt"},
+		{"w-[37px]", "w"},
+		{"text-lg", "font-size"},
+		{"text-[14px]", "font-size"},
+		{"text-center", "text-align"},
+		{"text-red-500", "text-color"},
+		{"text-[#fff]", "text-color"},
+		{"font-bold", "font-weight"},
+		{"font-[600]", "font-weight"},
+		{"font-mono", "font-family"},
+		{"border", "border-w"},
+		{"border-2", "border-w"},
+		{"border-t-4", "border-w-t"},
+		{"border-dashed", "border-style"},
+		{"border-red-500", "border-color"},
+		{"rounded-t-lg", "rounded-t"},
+		{"rounded", "rounded"},
+		{"bg-blue-500", "bg-color"},
+		{"bg-cover", "bg-size"},
+		{"bg-[url(/a.png)]", "bg-image"},
+		{"shadow-lg", "shadow"},
+		{"shadow-red-500", "shadow-color"},
+		{"btn", ""},
+		{"card-header", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.utility, func(t *testing.T) {
+			if got := tailwindGroup(tt.utility); got != tt.want {
+				t.Errorf("tailwindGroup(%q) = %q, want %q", tt.utility, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTailwindMerger(t *testing.T) {
+	tests := []struct {
+		classes string
+		want    string
+	}{
+		{"p-2 p-4", "p-4"},
+		{"px-2 pt-1 p-4", "p-4"},
+		{"p-4 px-2", "p-4 px-2"},
+		{"text-lg text-red-500 text-sm", "text-red-500 text-sm"},
+		{"leading-7 text-lg", "text-lg"},
+		{"block flex", "flex"},
+		{"border border-red-500 border-2", "border-red-500 border-2"},
+		{"hover:bg-red-500 bg-blue-500 hover:bg-green-500", "bg-blue-500 hover:bg-green-500"},
+		{"md:hover:p-2 hover:md:p-4", "hover:md:p-4"},
+		{"!p-2 p-4 !p-6", "p-4 !p-6"},
+		{"p-2! !p-4", "!p-4"},
+		{"w-[10px] w-1/2", "w-1/2"},
+		{"btn p-2 btn-primary p-4", "btn btn-primary p-4"},
+		{"-mt-2 mt-4", "mt-4"},
+	}
+
+	m := TailwindMerger()
+	for _, tt := range tests {
+		t.Run(tt.classes, func(t *testing.T) {
+			got := strings.Join(m.MergeClasses(strings.Fields(tt.classes)), " ")
+			if got != tt.want {
+				t.Errorf("MergeClasses(%q) = %q, want %q", tt.classes, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTailwindMergerCustomGroup(t *testing.T) {
+	m := TailwindMerger()
+	group := m.Group
+	m.Group = func(utility string) string {
+		if strings.HasPrefix(utility, "btn-") {
+			return "btn"
+		}
+		return group(utility)
+	}
+
+	got := m.MergeClasses([]string{"btn-primary", "p-2", "btn-danger", "p-4"})
+	if want := []string{"btn-danger", "p-4"}; !slices.Equal(got, want) {
+		t.Errorf("MergeClasses = %q, want %q", got, want)
+	}
+}
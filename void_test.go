@@ -0,0 +1,96 @@
+package htmlsimple
+
+import "testing"
+
+func TestVoidElements(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  RenderOptions
+		build func(root *Element)
+		want  string
+	}{
+		{
+			name: "attributes",
+			build: func(root *Element) {
+				root.Img().Attr("src", "/a.png").WithAttrs(KV("alt", "A"), KV("width", "10"))
+			},
+			want: `<img alt="A" src="/a.png" width="10" />`,
+		},
+		{
+			name: "parent continues the chain",
+			build: func(root *Element) {
+				root.P().AddString("a").Br().Parent().Span().AddString("b")
+			},
+			want: `<p>a<br /><span>b</span></p>`,
+		},
+		{
+			name: "minified",
+			opts: RenderOptions{Minify: true},
+			build: func(root *Element) {
+				root.P().Br()
+			},
+			want: `<p><br></p>`,
+		},
+		{
+			name: "xhtml",
+			opts: RenderOptions{XHTML: true},
+			build: func(root *Element) {
+				root.Hr()
+			},
+			want: `<hr xmlns="http://www.w3.org/1999/xhtml" />`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(nil)
+			tt.build(g.Root)
+			if got := g.GenerateWith(tt.opts); got != tt.want {
+				t.Errorf("GenerateWith = %q, want %q", got, tt.want)
+			}
+			if len(g.Diagnostics()) > 0 {
+				t.Errorf("unexpected diagnostics %v", g.Diagnostics())
+			}
+		})
+	}
+}
+
+func TestVoidElementContentIsReported(t *testing.T) {
+	tests := []struct {
+		name   string
+		misuse func(g *Generator, br *Element)
+		reason string
+	}{
+		{
+			name:   "text",
+			misuse: func(g *Generator, br *Element) { br.AddString("x") },
+			reason: "text added to void element is not rendered",
+		},
+		{
+			name:   "child",
+			misuse: func(g *Generator, br *Element) { br.Span() },
+			reason: "<span> added to void element is not rendered",
+		},
+		{
+			name:   "appended child",
+			misuse: func(g *Generator, br *Element) { br.Append(g.Root.Span()) },
+			reason: "element appended to void element is not rendered",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(nil)
+			br := g.Root.Div().Br().element
+			tt.misuse(g, br)
+
+			if got, want := g.Generate(), `<div><br /></div>`; got != want {
+				t.Errorf("Generate = %q, want %q", got, want)
+			}
+			d := g.Diagnostics()
+			if len(d) != 1 || d[0].Severity != SeverityError || d[0].Reason != tt.reason || d[0].Path != "div > br" {
+				t.Errorf("Diagnostics = %v, want error %q at div > br", d, tt.reason)
+			}
+		})
+	}
+}
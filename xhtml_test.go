@@ -0,0 +1,100 @@
+package htmlsimple
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// wellFormed reports the first XML syntax error in s, if any.
+func wellFormed(s string) error {
+	d := xml.NewDecoder(strings.NewReader(s))
+	for {
+		_, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func TestXHTML(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  RenderOptions
+		build func(root *Element) *Element
+		want  string
+	}{
+		{
+			name: "namespace on outermost element",
+			build: func(root *Element) *Element {
+				root.Html().Body().P().AddString("hi")
+				return root
+			},
+			want: `<html xmlns="http://www.w3.org/1999/xhtml"><body><p>hi</p></body></html>`,
+		},
+		{
+			name: "namespace on rendered subtree",
+			build: func(root *Element) *Element {
+				return root.Html().Body().Div()
+			},
+			want: `<div xmlns="http://www.w3.org/1999/xhtml" />`,
+		},
+		{
+			name: "xml escapes",
+			build: func(root *Element) *Element {
+				return root.P().Attr("title", `a'b"c`).AddString(`it's "q" & <x>`)
+			},
+			want: `<p xmlns="http://www.w3.org/1999/xhtml" title="a&apos;b&quot;c">it&apos;s &quot;q&quot; &amp; &lt;x&gt;</p>`,
+		},
+		{
+			name: "void and boolean attributes",
+			build: func(root *Element) *Element {
+				div := root.Div()
+				div.Input().Attr("checked", "").Attr("type", "checkbox")
+				div.Br()
+				return div
+			},
+			want: `<div xmlns="http://www.w3.org/1999/xhtml"><input checked="checked" type="checkbox" /><br /></div>`,
+		},
+		{
+			name: "foreign namespaces",
+			build: func(root *Element) *Element {
+				body := root.Body()
+				body.Svg().SvgUse().Attr("xlink:href", "#a")
+				body.Math().Mi().AddString("x")
+				return body
+			},
+			want: `<body xmlns="http://www.w3.org/1999/xhtml"><svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink"><use xlink:href="#a" /></svg><math xmlns="http://www.w3.org/1998/Math/MathML"><mi>x</mi></math></body>`,
+		},
+		{
+			name: "minify is ignored",
+			opts: RenderOptions{Minify: true},
+			build: func(root *Element) *Element {
+				ul := root.Ul()
+				ul.Li().Attr("class", "a")
+				return ul
+			},
+			want: `<ul xmlns="http://www.w3.org/1999/xhtml"><li class="a" /></ul>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := tt.build(New(nil).Root)
+			opts := tt.opts
+			opts.XHTML = true
+			got := e.Render(opts)
+			if got != tt.want {
+				t.Errorf("Render = %q, want %q", got, tt.want)
+			}
+			if err := wellFormed(got); err != nil {
+				t.Errorf("output is not well-formed XML: %v", err)
+			}
+		})
+	}
+}